@@ -0,0 +1,204 @@
+package Augmentation
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+type Config struct {
+	// Image size, 28 x 28 for MNIST
+	Width  int
+	Height int
+
+	// Maximum shift in pixels in x and y direction
+	MaxTranslation float64
+
+	// Maximum rotation in degrees, clockwise and anti-clockwise
+	MaxRotation float64
+
+	// Maximum relative change in size, i.e. 0.1 scales by a factor in [0.9, 1.1]
+	MaxScale float64
+
+	// Elastic distortion, see Simard et al., "Best Practices for Convolutional
+	// Neural Networks Applied to Visual Document Analysis". Alpha is the
+	// intensity of the displacement field in pixels, Sigma the standard
+	// deviation of the Gaussian used to smooth it. Disabled if Alpha is 0.
+	ElasticAlpha float64
+	ElasticSigma float64
+
+	// Standard deviation of the Gaussian noise added to each pixel
+	NoiseStdDev float64
+
+	// Augmenters with the same seed generate the same sequence of images
+	Seed int64
+}
+
+func MNISTConfig() Config {
+	return Config{Width: 28, Height: 28, MaxTranslation: 2, MaxRotation: 10, MaxScale: 0.1, ElasticAlpha: 1.5, ElasticSigma: 4, NoiseStdDev: 0.05}
+}
+
+// Generates randomly perturbed copies of images. All buffers are allocated
+// once, so an Augmenter must not be shared between goroutines.
+type Augmenter struct {
+	config Config
+	rng    *rand.Rand
+
+	// elastic displacement field and scratch buffer for smoothing
+	dx  []float64
+	dy  []float64
+	tmp []float64
+
+	// 1D Gaussian kernel used to smooth the displacement field
+	kernel []float64
+}
+
+func CreateAugmenter(config Config) *Augmenter {
+	size := config.Width * config.Height
+	a := &Augmenter{config: config, rng: rand.New(rand.NewSource(config.Seed))}
+	if config.ElasticAlpha != 0 {
+		a.dx = make([]float64, size)
+		a.dy = make([]float64, size)
+		a.tmp = make([]float64, size)
+		a.kernel = gaussianKernel(config.ElasticSigma)
+	}
+	return a
+}
+
+func (a *Augmenter) String() string {
+	c := a.config
+	return fmt.Sprintf("translation %.1fpx, rotation %.1f°, scale %.2f, elastic %.1f/%.1f, noise %.3f", c.MaxTranslation, c.MaxRotation, c.MaxScale, c.ElasticAlpha, c.ElasticSigma, c.NoiseStdDev)
+}
+
+// Writes a randomly perturbed copy of 'src' into 'dst'
+func (a *Augmenter) Apply(dst *LinAlg.Vector, src *LinAlg.Vector) {
+	c := a.config
+	if size := c.Width * c.Height; src.Size() != size || dst.Size() != size {
+		panic(fmt.Sprintf("Augmentation.Augmenter.Apply: Images must have size %d, but are %d and %d", size, src.Size(), dst.Size()))
+	}
+	angle := a.uniform(c.MaxRotation) * math.Pi / 180
+	scale := 1 + a.uniform(c.MaxScale)
+	tx := a.uniform(c.MaxTranslation)
+	ty := a.uniform(c.MaxTranslation)
+	if c.ElasticAlpha != 0 {
+		a.generateDisplacementField()
+	}
+	a.warp(dst, src, angle, scale, tx, ty)
+	if c.NoiseStdDev != 0 {
+		for idx := 0; idx < dst.Size(); idx++ {
+			value := dst.Get(idx) + a.rng.NormFloat64()*c.NoiseStdDev
+			dst.Set(idx, math.Max(0, math.Min(1, value)))
+		}
+	}
+}
+
+// uniformly distributed in [-max, max]
+func (a *Augmenter) uniform(max float64) float64 {
+	if max == 0 {
+		return 0
+	}
+	return (2*a.rng.Float64() - 1) * max
+}
+
+// For each pixel p of the output image, find the pixel q of the input image
+// that is moved to p by rotating and scaling around the image center and
+// translating, i.e. q = c + R^{-1} (p - c - t) / s, then displace it by the
+// elastic field and interpolate.
+func (a *Augmenter) warp(dst *LinAlg.Vector, src *LinAlg.Vector, angle float64, scale float64, tx float64, ty float64) {
+	w := a.config.Width
+	h := a.config.Height
+	cx := float64(w-1) / 2
+	cy := float64(h-1) / 2
+	cos := math.Cos(angle) / scale
+	sin := math.Sin(angle) / scale
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			px := float64(col) - cx - tx
+			py := float64(row) - cy - ty
+			qx := cos*px + sin*py + cx
+			qy := -sin*px + cos*py + cy
+			if a.dx != nil {
+				idx := row*w + col
+				qx += a.dx[idx]
+				qy += a.dy[idx]
+			}
+			dst.Set(row*w+col, bilinear(src, w, h, qx, qy))
+		}
+	}
+}
+
+func bilinear(img *LinAlg.Vector, w int, h int, x float64, y float64) float64 {
+	x0 := int(math.Floor(x))
+	y0 := int(math.Floor(y))
+	fx := x - float64(x0)
+	fy := y - float64(y0)
+	pixel := func(col int, row int) float64 {
+		if col < 0 || col >= w || row < 0 || row >= h {
+			return 0
+		}
+		return img.Get(row*w + col)
+	}
+	top := (1-fx)*pixel(x0, y0) + fx*pixel(x0+1, y0)
+	bottom := (1-fx)*pixel(x0, y0+1) + fx*pixel(x0+1, y0+1)
+	return (1-fy)*top + fy*bottom
+}
+
+func (a *Augmenter) generateDisplacementField() {
+	for _, field := range [][]float64{a.dx, a.dy} {
+		for idx := range field {
+			field[idx] = 2*a.rng.Float64() - 1
+		}
+		a.smooth(field)
+		for idx := range field {
+			field[idx] *= a.config.ElasticAlpha
+		}
+	}
+}
+
+// Separable Gaussian blur, in place
+func (a *Augmenter) smooth(field []float64) {
+	w := a.config.Width
+	h := a.config.Height
+	radius := len(a.kernel) / 2
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			var value float64
+			for k, weight := range a.kernel {
+				if c := col + k - radius; c >= 0 && c < w {
+					value += weight * field[row*w+c]
+				}
+			}
+			a.tmp[row*w+col] = value
+		}
+	}
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			var value float64
+			for k, weight := range a.kernel {
+				if r := row + k - radius; r >= 0 && r < h {
+					value += weight * a.tmp[r*w+col]
+				}
+			}
+			field[row*w+col] = value
+		}
+	}
+}
+
+func gaussianKernel(sigma float64) []float64 {
+	if sigma <= 0 {
+		return []float64{1}
+	}
+	radius := int(math.Ceil(3 * sigma))
+	kernel := make([]float64, 2*radius+1)
+	var sum float64
+	for idx := range kernel {
+		x := float64(idx - radius)
+		kernel[idx] = math.Exp(-x * x / (2 * sigma * sigma))
+		sum += kernel[idx]
+	}
+	for idx := range kernel {
+		kernel[idx] /= sum
+	}
+	return kernel
+}
@@ -0,0 +1,109 @@
+package Augmentation
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"math"
+	"testing"
+)
+
+const (
+	EPSILON = 0.00000001
+)
+
+func floatEquals(a, b float64, eps float64) bool {
+	return math.Abs(a-b) < eps
+}
+
+func createTestImage(w int, h int) *LinAlg.Vector {
+	img := LinAlg.MakeEmptyVector(w * h)
+	for row := 2; row < h-2; row++ {
+		for col := 3; col < w-3; col++ {
+			img.Set(row*w+col, float64(row*w+col)/float64(w*h))
+		}
+	}
+	return img
+}
+
+func TestApplyIdentity(t *testing.T) {
+	// Arrange
+	a := CreateAugmenter(Config{Width: 8, Height: 6})
+	src := createTestImage(8, 6)
+	dst := LinAlg.MakeEmptyVector(8 * 6)
+
+	// Act
+	a.Apply(dst, src)
+
+	// Assert
+	for idx := 0; idx < src.Size(); idx++ {
+		if floatEquals(dst.Get(idx), src.Get(idx), EPSILON) == false {
+			t.Errorf("Pixel %d must be unchanged, %f != %f", idx, src.Get(idx), dst.Get(idx))
+		}
+	}
+}
+
+func TestWarpTranslation(t *testing.T) {
+	// Arrange
+	a := CreateAugmenter(Config{Width: 8, Height: 6})
+	src := createTestImage(8, 6)
+	dst := LinAlg.MakeEmptyVector(8 * 6)
+
+	// Act
+	a.warp(dst, src, 0, 1, 1, 2)
+
+	// Assert
+	for row := 2; row < 6; row++ {
+		for col := 1; col < 8; col++ {
+			if expected := src.Get((row-2)*8 + col - 1); floatEquals(dst.Get(row*8+col), expected, EPSILON) == false {
+				t.Errorf("Pixel (%d, %d) must be %f, but is %f", row, col, expected, dst.Get(row*8+col))
+			}
+		}
+	}
+}
+
+func TestWarpRotation(t *testing.T) {
+	// Arrange
+	a := CreateAugmenter(Config{Width: 5, Height: 5})
+	src := LinAlg.MakeEmptyVector(25)
+	src.Set(2*5+4, 1)
+	dst := LinAlg.MakeEmptyVector(25)
+
+	// Act
+	a.warp(dst, src, math.Pi/2, 1, 0, 0)
+
+	// Assert
+	if floatEquals(dst.Get(4*5+2), 1, EPSILON) == false {
+		t.Errorf("Pixel (2, 4) must be rotated to (4, 2)")
+	}
+}
+
+func TestApplySeed(t *testing.T) {
+	// Arrange
+	config := MNISTConfig()
+	config.Seed = 42
+	a1 := CreateAugmenter(config)
+	a2 := CreateAugmenter(config)
+	src := createTestImage(28, 28)
+	dst1 := LinAlg.MakeEmptyVector(28 * 28)
+	dst2 := LinAlg.MakeEmptyVector(28 * 28)
+	dst3 := LinAlg.MakeEmptyVector(28 * 28)
+
+	// Act
+	a1.Apply(dst1, src)
+	a2.Apply(dst2, src)
+	a1.Apply(dst3, src)
+
+	// Assert
+	var different bool
+	for idx := 0; idx < src.Size(); idx++ {
+		if dst1.Get(idx) != dst2.Get(idx) {
+			t.Fatalf("Augmenters with same seed must generate the same images")
+		}
+		if value := dst1.Get(idx); value < 0 || value > 1 {
+			t.Errorf("Pixel %d must be in [0, 1], but is %f", idx, value)
+		}
+		different = different || dst1.Get(idx) != dst3.Get(idx)
+	}
+	if different == false {
+		t.Error("Consecutive calls must generate different images")
+	}
+}
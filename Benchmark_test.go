@@ -0,0 +1,135 @@
+package main
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"SimpleNeuralNet/MNISTImport"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+var benchmarkLayers = [][]int{{28 * 28, 30, 10}, {28 * 28, 100, 10}}
+
+func layersName(layers []int) string {
+	return fmt.Sprint(layers)
+}
+
+func createBenchmarkNetwork(layers []int) Network {
+	network := CreateNetwork(layers)
+	network.InitializeNetworkWeightsAndBiasesFrom(rand.New(rand.NewSource(1)))
+	return network
+}
+
+func importBenchmarkSamples() []MNISTImport.TrainingSample {
+	data := MNISTImport.ImportData("./test_data/", "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	return data.GenerateTrainingSamples(data.Length())
+}
+
+func BenchmarkFeedforward(b *testing.B) {
+	ts := importBenchmarkSamples()
+	for _, layers := range benchmarkLayers {
+		network := createBenchmarkNetwork(layers)
+		mb := CreateMiniBatch(layers)
+		b.Run(layersName(layers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				mb.a[0] = ts[i%len(ts)].InputActivations
+				network.Feedforward(&mb)
+			}
+		})
+	}
+}
+
+func BenchmarkFeedforwardSparse(b *testing.B) {
+	ts := importBenchmarkSamples()
+	for _, layers := range benchmarkLayers {
+		network := createBenchmarkNetwork(layers)
+		mb := CreateMiniBatch(layers)
+		b.Run(layersName(layers), func(b *testing.B) {
+			inputs := make([]*LinAlg.SparseVector, len(ts))
+			for idx := range ts {
+				inputs[idx] = ts[idx].InputActivations.ToSparse()
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				network.FeedforwardSparse(inputs[i%len(inputs)], &mb)
+			}
+		})
+	}
+}
+
+func BenchmarkBackpropagateError(b *testing.B) {
+	ts := importBenchmarkSamples()
+	for _, layers := range benchmarkLayers {
+		network := createBenchmarkNetwork(layers)
+		mb := CreateMiniBatch(layers)
+		mb.a[0] = ts[0].InputActivations
+		network.Feedforward(&mb)
+		QuadraticCostFunction{}.CalculateErrorInOutputLayer(&network, &ts[0].OutputActivations, &mb)
+		b.Run(layersName(layers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				network.BackpropagateError(&mb)
+			}
+		})
+	}
+}
+
+// One SGD step per minibatch of 10 samples, as in TrainSource, without the
+// per-epoch evaluation
+func BenchmarkTrainingStep(b *testing.B) {
+	ts := importBenchmarkSamples()
+	const miniBatchSize = 10
+	for _, layers := range benchmarkLayers {
+		network := createBenchmarkNetwork(layers)
+		mbs := CreateMiniBatches(miniBatchSize, layers)
+		dw, db := network.createDerivatives()
+		var costFunction CostFunction = CrossEntropyCostFunction{}
+		b.Run(layersName(layers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for idx := range mbs {
+					mb := &mbs[idx]
+					x := &ts[(i*miniBatchSize+idx)%len(ts)]
+					mb.a[0] = x.InputActivations
+					network.Feedforward(mb)
+					costFunction.CalculateErrorInOutputLayer(&network, &x.OutputActivations, mb)
+					network.BackpropagateError(mb)
+				}
+				network.calculateDerivativesTo(dw, db, mbs)
+				network.UpdateNetwork(0.5, 0, dw, db, len(ts))
+			}
+			b.ReportMetric(float64(b.N*miniBatchSize)/b.Elapsed().Seconds(), "samples/s")
+		})
+	}
+}
+
+// One epoch of Train on the fixture, including the accuracy evaluation
+func BenchmarkTrainEpoch(b *testing.B) {
+	ts := importBenchmarkSamples()
+
+	// Train reports its progress on stdout
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer devNull.Close()
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	for _, layers := range benchmarkLayers {
+		network := createBenchmarkNetwork(layers)
+		b.Run(layersName(layers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				network.Train(ts, nil, 1, 0.5, 0, 10, QuadraticCostFunction{})
+			}
+			b.ReportMetric(float64(b.N*len(ts))/b.Elapsed().Seconds(), "samples/s")
+		})
+	}
+}
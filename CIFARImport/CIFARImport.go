@@ -0,0 +1,122 @@
+package CIFARImport
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"SimpleNeuralNet/MNISTImport"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+)
+
+// Input activations are stored channel by channel (red, green, blue), each
+// channel row by row, exactly as in the CIFAR-10 binary format. Dense layers
+// can use the vector as is, convolutional layers see it as a
+// Channels x Height x Width tensor.
+const (
+	Channels  = 3
+	Height    = 32
+	Width     = 32
+	ImageSize = Channels * Height * Width
+
+	// label byte followed by the image
+	recordSize = 1 + ImageSize
+)
+
+var TrainingBatchFiles = []string{"data_batch_1.bin", "data_batch_2.bin", "data_batch_3.bin", "data_batch_4.bin", "data_batch_5.bin"}
+
+const TestBatchFile = "test_batch.bin"
+
+const MetaFile = "batches.meta.txt"
+
+var ClassNames = []string{"airplane", "automobile", "bird", "cat", "deer", "dog", "frog", "horse", "ship", "truck"}
+
+type CIFARData struct {
+	inputActivations [][]float64
+	expectedResult   []byte
+}
+
+// Index of a pixel in the input activations
+func PixelIndex(channel int, row int, col int) int {
+	return channel*Height*Width + row*Width + col
+}
+
+func BuildFromBatchFile(data []byte) ([][]float64, []byte) {
+	if len(data)%recordSize != 0 {
+		panic(fmt.Sprintf("CIFARImport: Batch file size %d is not a multiple of the record size %d", len(data), recordSize))
+	}
+	nImages := len(data) / recordSize
+	images := make([][]float64, nImages)
+	labels := make([]byte, nImages)
+	for imageIdx := 0; imageIdx < nImages; imageIdx++ {
+		record := data[imageIdx*recordSize : (imageIdx+1)*recordSize]
+		label := record[0]
+		if int(label) >= len(ClassNames) {
+			panic(fmt.Sprintf("CIFARImport: Label %d of image %d out of range", label, imageIdx))
+		}
+		labels[imageIdx] = label
+		img := make([]float64, ImageSize)
+		for idx, value := range record[1:] {
+			img[idx] = float64(value) / 255
+		}
+		images[imageIdx] = img
+	}
+	return images, labels
+}
+
+func ImportBatchFile(fileName string) CIFARData {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		panic("Failed to read data")
+	}
+	var output CIFARData
+	output.inputActivations, output.expectedResult = BuildFromBatchFile(data)
+	return output
+}
+
+func ImportData(dir string, batchFiles ...string) CIFARData {
+	var output CIFARData
+	for _, batchFile := range batchFiles {
+		batch := ImportBatchFile(path.Join(dir, batchFile))
+		output.inputActivations = append(output.inputActivations, batch.inputActivations...)
+		output.expectedResult = append(output.expectedResult, batch.expectedResult...)
+	}
+	return output
+}
+
+// Read the class names from 'batches.meta.txt', one name per line
+func ImportClassNames(fileName string) []string {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		panic("Failed to read data")
+	}
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (data CIFARData) Length() int {
+	return len(data.inputActivations)
+}
+
+func (data CIFARData) GetResult(index int) byte {
+	return data.expectedResult[index]
+}
+
+func (data CIFARData) GenerateTrainingSamples(length int) []MNISTImport.TrainingSample {
+	if length > data.Length() {
+		length = data.Length()
+	}
+	tss := make([]MNISTImport.TrainingSample, length)
+	for idx := range tss {
+		ts := &tss[idx]
+		ts.InputActivations = *LinAlg.MakeVector(data.inputActivations[idx])
+		ts.OutputActivations = *LinAlg.MakeEmptyVector(len(ClassNames))
+		ts.OutputActivations.Set(int(data.expectedResult[idx]), 1)
+	}
+	return tss
+}
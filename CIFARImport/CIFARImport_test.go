@@ -0,0 +1,86 @@
+package CIFARImport
+
+import (
+	"io/ioutil"
+	"math"
+	"path"
+	"testing"
+)
+
+const (
+	EPSILON = 0.00000001
+)
+
+func floatEquals(a, b float64, eps float64) bool {
+	return math.Abs(a-b) < eps
+}
+
+func createBatch(labels ...byte) []byte {
+	data := make([]byte, 0, len(labels)*recordSize)
+	for imageIdx, label := range labels {
+		data = append(data, label)
+		for idx := 0; idx < ImageSize; idx++ {
+			data = append(data, byte((idx+imageIdx)%256))
+		}
+	}
+	return data
+}
+
+func TestBuildFromBatchFile(t *testing.T) {
+	// Arrange
+	data := createBatch(3, 9)
+
+	// Act
+	images, labels := BuildFromBatchFile(data)
+
+	// Assert
+	if len(images) != 2 || len(labels) != 2 {
+		t.Fatalf("Expected 2 images, but got %d", len(images))
+	}
+	if labels[0] != 3 || labels[1] != 9 {
+		t.Errorf("Unexpected labels %v", labels)
+	}
+	if size := len(images[1]); size != ImageSize {
+		t.Fatalf("Image must have size %d, but is %d", ImageSize, size)
+	}
+	idx := PixelIndex(2, 5, 7)
+	if expected := float64((idx+1)%256) / 255; floatEquals(images[1][idx], expected, EPSILON) == false {
+		t.Errorf("Pixel must be %f, but is %f", expected, images[1][idx])
+	}
+}
+
+func TestImportData(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(path.Join(dir, "b1.bin"), createBatch(1), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, "b2.bin"), createBatch(7, 2), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, MetaFile), []byte("airplane\nautomobile\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	data := ImportData(dir, "b1.bin", "b2.bin")
+	ts := data.GenerateTrainingSamples(data.Length())
+	names := ImportClassNames(path.Join(dir, MetaFile))
+
+	// Assert
+	if data.Length() != 3 {
+		t.Fatalf("Expected 3 images, but got %d", data.Length())
+	}
+	if data.GetResult(1) != 7 {
+		t.Errorf("Expected label 7, but got %d", data.GetResult(1))
+	}
+	if size := ts[1].OutputActivations.Size(); size != 10 {
+		t.Errorf("Output activations must have size 10, but is %d", size)
+	}
+	if ts[1].OutputActivations.Get(7) != 1 {
+		t.Error("Output activation 7 must be 1")
+	}
+	if len(names) != 2 || names[1] != "automobile" {
+		t.Errorf("Unexpected class names %v", names)
+	}
+}
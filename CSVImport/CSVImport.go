@@ -0,0 +1,355 @@
+package CSVImport
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"SimpleNeuralNet/MNISTImport"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type MissingValuePolicy int
+
+const (
+	// Return an error when a value is missing
+	MissingValueError MissingValuePolicy = iota
+
+	// Drop rows with missing values
+	MissingValueSkipRow
+
+	// Replace missing values by 0 (all-zero encoding for categorical columns)
+	MissingValueZero
+
+	// Replace missing values by the column mean (category frequencies for categorical columns)
+	MissingValueMean
+)
+
+type Options struct {
+	// First row contains the column names
+	HasHeader bool
+
+	// Field delimiter, ',' if not set
+	Comma rune
+
+	// Columns used as input activations, either by header name or by
+	// zero-based index. All columns except the label column if empty.
+	InputColumns []string
+
+	// Column holding the class label (classification) or target value (regression)
+	LabelColumn string
+
+	// Input columns holding categories instead of numbers. Each is one-hot
+	// encoded into one input activation per distinct value.
+	CategoricalColumns []string
+
+	// If set, the label column is used as raw target instead of a one-hot class
+	Regression bool
+
+	MissingValues MissingValuePolicy
+
+	// Values treated as missing in addition to the empty string, i.e. "NA"
+	MissingTokens []string
+}
+
+type CSVData struct {
+	// Names of the input activations after categorical encoding
+	InputNames []string
+
+	// Class labels in the order of the output activations, empty for regression
+	Classes []string
+
+	inputActivations  [][]float64
+	outputActivations [][]float64
+}
+
+type column struct {
+	index       int
+	name        string
+	categorical bool
+
+	// distinct values of a categorical column
+	categories []string
+}
+
+func ImportFile(fileName string, options Options) (*CSVData, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Import(file, options)
+}
+
+func Import(r io.Reader, options Options) (*CSVData, error) {
+	reader := csv.NewReader(r)
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	var header []string
+	if options.HasHeader {
+		if len(records) == 0 {
+			return nil, fmt.Errorf("CSVImport.Import: Header row missing")
+		}
+		header = records[0]
+		records = records[1:]
+	}
+	nColumns := len(header)
+	if len(records) > 0 {
+		nColumns = len(records[0])
+	}
+
+	labelIdx, err := resolveColumn(options.LabelColumn, header, nColumns)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := resolveInputColumns(options, header, nColumns, labelIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := func(value string) bool {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return true
+		}
+		for _, token := range options.MissingTokens {
+			if value == token {
+				return true
+			}
+		}
+		return false
+	}
+
+	// drop rows with missing labels, and with missing inputs if requested
+	rows := make([][]string, 0, len(records))
+	for rowIdx, record := range records {
+		if missing(record[labelIdx]) {
+			if options.MissingValues == MissingValueError {
+				return nil, fmt.Errorf("CSVImport.Import: Row %d has no label", rowIdx+1)
+			}
+			continue
+		}
+		skip := false
+		for _, c := range inputs {
+			if missing(record[c.index]) {
+				if options.MissingValues == MissingValueError {
+					return nil, fmt.Errorf("CSVImport.Import: Row %d has no value for column %s", rowIdx+1, c.name)
+				}
+				skip = options.MissingValues == MissingValueSkipRow
+			}
+		}
+		if skip == false {
+			rows = append(rows, record)
+		}
+	}
+
+	data := &CSVData{}
+	for idx := range inputs {
+		c := &inputs[idx]
+		if c.categorical == false {
+			data.InputNames = append(data.InputNames, c.name)
+			continue
+		}
+		c.categories = distinctValues(rows, c.index, missing)
+		for _, category := range c.categories {
+			data.InputNames = append(data.InputNames, c.name+"="+category)
+		}
+	}
+
+	// column means, used to replace missing values
+	nInputs := len(data.InputNames)
+	means := make([]float64, nInputs)
+	counts := make([]int, nInputs)
+	data.inputActivations = make([][]float64, len(rows))
+	present := make([][]bool, len(rows))
+	for rowIdx, record := range rows {
+		activations := make([]float64, nInputs)
+		isPresent := make([]bool, nInputs)
+		offset := 0
+		for _, c := range inputs {
+			value := strings.TrimSpace(record[c.index])
+			width := 1
+			if c.categorical {
+				width = len(c.categories)
+			}
+			if missing(value) == false {
+				if c.categorical {
+					activations[offset+indexOf(c.categories, value)] = 1
+				} else {
+					f, err := strconv.ParseFloat(value, 64)
+					if err != nil {
+						return nil, fmt.Errorf("CSVImport.Import: Row %d, column %s: %v", rowIdx+1, c.name, err)
+					}
+					activations[offset] = f
+				}
+				for k := offset; k < offset+width; k++ {
+					isPresent[k] = true
+					means[k] += activations[k]
+					counts[k]++
+				}
+			}
+			offset += width
+		}
+		data.inputActivations[rowIdx] = activations
+		present[rowIdx] = isPresent
+	}
+	if options.MissingValues == MissingValueMean {
+		for idx := range means {
+			if counts[idx] > 0 {
+				means[idx] /= float64(counts[idx])
+			}
+		}
+		for rowIdx, activations := range data.inputActivations {
+			for idx := range activations {
+				if present[rowIdx][idx] == false {
+					activations[idx] = means[idx]
+				}
+			}
+		}
+	}
+
+	data.outputActivations = make([][]float64, len(rows))
+	if options.Regression {
+		for rowIdx, record := range rows {
+			f, err := strconv.ParseFloat(strings.TrimSpace(record[labelIdx]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("CSVImport.Import: Row %d, label: %v", rowIdx+1, err)
+			}
+			data.outputActivations[rowIdx] = []float64{f}
+		}
+		return data, nil
+	}
+	data.Classes = distinctValues(rows, labelIdx, missing)
+	for rowIdx, record := range rows {
+		output := make([]float64, len(data.Classes))
+		output[data.GetClass(strings.TrimSpace(record[labelIdx]))] = 1
+		data.outputActivations[rowIdx] = output
+	}
+	return data, nil
+}
+
+func resolveColumn(name string, header []string, nColumns int) (int, error) {
+	for idx, h := range header {
+		if strings.TrimSpace(h) == name {
+			return idx, nil
+		}
+	}
+	idx, err := strconv.Atoi(name)
+	if err != nil || idx < 0 || idx >= nColumns {
+		return 0, fmt.Errorf("CSVImport: Unknown column %q", name)
+	}
+	return idx, nil
+}
+
+func resolveInputColumns(options Options, header []string, nColumns int, labelIdx int) ([]column, error) {
+	categorical := make(map[int]bool)
+	for _, name := range options.CategoricalColumns {
+		idx, err := resolveColumn(name, header, nColumns)
+		if err != nil {
+			return nil, err
+		}
+		categorical[idx] = true
+	}
+	var indices []int
+	if len(options.InputColumns) == 0 {
+		for idx := 0; idx < nColumns; idx++ {
+			if idx != labelIdx {
+				indices = append(indices, idx)
+			}
+		}
+	}
+	for _, name := range options.InputColumns {
+		idx, err := resolveColumn(name, header, nColumns)
+		if err != nil {
+			return nil, err
+		}
+		indices = append(indices, idx)
+	}
+	columns := make([]column, len(indices))
+	for i, idx := range indices {
+		name := strconv.Itoa(idx)
+		if idx < len(header) {
+			name = strings.TrimSpace(header[idx])
+		}
+		columns[i] = column{index: idx, name: name, categorical: categorical[idx]}
+	}
+	return columns, nil
+}
+
+func distinctValues(rows [][]string, col int, missing func(string) bool) []string {
+	seen := make(map[string]bool)
+	var values []string
+	for _, record := range rows {
+		value := strings.TrimSpace(record[col])
+		if missing(value) || seen[value] {
+			continue
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+	sortValues(values)
+	return values
+}
+
+// Sort numerically if all values are numbers, so that i.e. "10" comes after "9"
+func sortValues(values []string) {
+	type numericValue struct {
+		label  string
+		number float64
+	}
+	numbers := make([]numericValue, len(values))
+	for idx, value := range values {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			sort.Strings(values)
+			return
+		}
+		numbers[idx] = numericValue{value, f}
+	}
+	sort.Slice(numbers, func(i, j int) bool {
+		return numbers[i].number < numbers[j].number
+	})
+	for idx := range numbers {
+		values[idx] = numbers[idx].label
+	}
+}
+
+func indexOf(values []string, value string) int {
+	for idx := range values {
+		if values[idx] == value {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (data CSVData) Length() int {
+	return len(data.inputActivations)
+}
+
+// Index of the output activation for class label 'label', or -1
+func (data CSVData) GetClass(label string) int {
+	return indexOf(data.Classes, label)
+}
+
+func (data CSVData) GenerateTrainingSamples(length int) []MNISTImport.TrainingSample {
+	if length > data.Length() {
+		length = data.Length()
+	}
+	tss := make([]MNISTImport.TrainingSample, length)
+	for idx := range tss {
+		ts := &tss[idx]
+		ts.InputActivations = *LinAlg.MakeVector(data.inputActivations[idx])
+		ts.OutputActivations = *LinAlg.MakeVector(data.outputActivations[idx])
+	}
+	return tss
+}
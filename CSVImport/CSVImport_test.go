@@ -0,0 +1,175 @@
+package CSVImport
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const (
+	EPSILON = 0.00000001
+)
+
+func floatEquals(a, b float64, eps float64) bool {
+	return math.Abs(a-b) < eps
+}
+
+const testData = `length,color,weight,species
+1.5,red,10,b
+2.5,green,,a
+3.5,red,30,c
+NA,blue,40,a
+`
+
+func TestImportClassification(t *testing.T) {
+	// Arrange
+	options := Options{HasHeader: true, LabelColumn: "species", CategoricalColumns: []string{"color"}, MissingValues: MissingValueZero, MissingTokens: []string{"NA"}}
+
+	// Act
+	data, err := Import(strings.NewReader(testData), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := data.GenerateTrainingSamples(data.Length())
+
+	// Assert
+	if len(ts) != 4 {
+		t.Fatalf("Expected 4 samples, but got %d", len(ts))
+	}
+	if expected := []string{"length", "color=blue", "color=green", "color=red", "weight"}; strings.Join(data.InputNames, " ") != strings.Join(expected, " ") {
+		t.Errorf("Unexpected input names %v", data.InputNames)
+	}
+	if expected := []string{"a", "b", "c"}; strings.Join(data.Classes, " ") != strings.Join(expected, " ") {
+		t.Errorf("Unexpected classes %v", data.Classes)
+	}
+	expectedInputs := [][]float64{{1.5, 0, 0, 1, 10}, {2.5, 0, 1, 0, 0}, {3.5, 0, 0, 1, 30}, {0, 1, 0, 0, 40}}
+	expectedClasses := []int{1, 0, 2, 0}
+	for idx := range ts {
+		x := ts[idx].InputActivations
+		for k, expected := range expectedInputs[idx] {
+			if floatEquals(x.Get(k), expected, EPSILON) == false {
+				t.Errorf("Sample %d, input %d: expected %f, but is %f", idx, k, expected, x.Get(k))
+			}
+		}
+		y := ts[idx].OutputActivations
+		if y.Size() != 3 {
+			t.Fatalf("Output activations must have size 3, but is %d", y.Size())
+		}
+		if y.Get(expectedClasses[idx]) != 1 {
+			t.Errorf("Sample %d must be of class %d", idx, expectedClasses[idx])
+		}
+	}
+}
+
+func TestImportMissingValueMean(t *testing.T) {
+	// Arrange
+	options := Options{HasHeader: true, LabelColumn: "species", InputColumns: []string{"length", "weight"}, MissingValues: MissingValueMean, MissingTokens: []string{"NA"}}
+
+	// Act
+	data, err := Import(strings.NewReader(testData), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := data.GenerateTrainingSamples(data.Length())
+
+	// Assert
+	if expected := float64(2.5); floatEquals(ts[3].InputActivations.Get(0), expected, EPSILON) == false {
+		t.Errorf("Missing length must be replaced by %f, but is %f", expected, ts[3].InputActivations.Get(0))
+	}
+	if expected := float64(80) / 3; floatEquals(ts[1].InputActivations.Get(1), expected, EPSILON) == false {
+		t.Errorf("Missing weight must be replaced by %f, but is %f", expected, ts[1].InputActivations.Get(1))
+	}
+}
+
+func TestImportMissingValueSkipRow(t *testing.T) {
+	// Arrange
+	options := Options{HasHeader: true, LabelColumn: "species", CategoricalColumns: []string{"color"}, MissingValues: MissingValueSkipRow, MissingTokens: []string{"NA"}}
+
+	// Act
+	data, err := Import(strings.NewReader(testData), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if expected := 2; data.Length() != expected {
+		t.Errorf("Expected %d rows, but got %d", expected, data.Length())
+	}
+}
+
+func TestImportMissingValueError(t *testing.T) {
+	// Arrange
+	options := Options{HasHeader: true, LabelColumn: "species", CategoricalColumns: []string{"color"}}
+
+	// Act
+	_, err := Import(strings.NewReader(testData), options)
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for missing values")
+	}
+}
+
+func TestImportRegressionWithoutHeader(t *testing.T) {
+	// Arrange
+	options := Options{LabelColumn: "2", Regression: true, Comma: ';'}
+
+	// Act
+	data, err := Import(strings.NewReader("1;2;0.25\n3;4;0.75\n"), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := data.GenerateTrainingSamples(data.Length())
+
+	// Assert
+	if len(data.Classes) != 0 {
+		t.Errorf("Regression data must not have classes")
+	}
+	if ts[1].InputActivations.Size() != 2 {
+		t.Fatalf("Input activations must have size 2, but is %d", ts[1].InputActivations.Size())
+	}
+	if expected := float64(4); floatEquals(ts[1].InputActivations.Get(1), expected, EPSILON) == false {
+		t.Errorf("Expected input %f, but is %f", expected, ts[1].InputActivations.Get(1))
+	}
+	if expected := 0.75; floatEquals(ts[1].OutputActivations.Get(0), expected, EPSILON) == false {
+		t.Errorf("Expected target %f, but is %f", expected, ts[1].OutputActivations.Get(0))
+	}
+}
+
+func TestNumericClassOrder(t *testing.T) {
+	// Arrange
+	options := Options{LabelColumn: "1"}
+
+	// Act
+	data, err := Import(strings.NewReader("0,10\n1,9\n2,2\n"), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if expected := "2 9 10"; strings.Join(data.Classes, " ") != expected {
+		t.Errorf("Expected classes %s, but got %v", expected, data.Classes)
+	}
+}
+
+func TestNumericClassOrderManyLabels(t *testing.T) {
+	// Arrange
+	options := Options{LabelColumn: "1"}
+	labels := []string{"10", "3", "9", "1", "7", "5", "2", "8", "6", "4"}
+	var csv strings.Builder
+	for idx, label := range labels {
+		csv.WriteString(strconv.Itoa(idx) + "," + label + "\n")
+	}
+
+	// Act
+	data, err := Import(strings.NewReader(csv.String()), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if expected := "1 2 3 4 5 6 7 8 9 10"; strings.Join(data.Classes, " ") != expected {
+		t.Errorf("Expected classes %s, but got %v", expected, data.Classes)
+	}
+}
@@ -50,7 +50,7 @@ func calculateDeltaCrossEntropy(layer int, n *Network, mb *Minibatch, ts *MNISTI
 	}
 	delta_next := calculateDeltaCrossEntropy(layer+1, n, mb, ts)
 	s := mb.z[layer].F(SigmoidPrime)
-	delta := n.GetWeights(layer + 1).Transpose().Ax(delta_next).Hadamard(s)
+	delta := n.GetWeights(layer + 1).TransposeAx(delta_next).Hadamard(s)
 	return delta
 }
 
@@ -97,5 +97,5 @@ func (CrossEntropyCostFunction) GradWeight(layer int, lambda float64, network *N
 func (CrossEntropyCostFunction) CalculateErrorInOutputLayer(n *Network, outputActivations *LinAlg.Vector, mb *Minibatch) {
 	// Equation (68), Chapter 3 of http://neuralnetworksanddeeplearning.com
 	outputLayerIdx := n.getOutputLayerIndex()
-	mb.delta[outputLayerIdx] = *LinAlg.SubtractVectors(&mb.a[outputLayerIdx], outputActivations)
+	LinAlg.SubtractVectorsTo(&mb.delta[outputLayerIdx], &mb.a[outputLayerIdx], outputActivations)
 }
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"SimpleNeuralNet/MNISTImport"
+	"fmt"
+	"math"
+)
+
+type CrossValidationResult struct {
+	// validation accuracy of each fold
+	Accuracies []float32
+
+	Mean   float64
+	StdDev float64
+}
+
+// k-fold cross-validation with stratified folds. 'train' creates and trains a
+// network on the training samples of each fold, its accuracy on the
+// remaining fold is the validation accuracy.
+func CrossValidate(data *MNISTImport.MNISTData, k int, seed int64, train func(trainingSamples []MNISTImport.TrainingSample, validationSamples []MNISTImport.TrainingSample) *Network) CrossValidationResult {
+	folds := data.StratifiedFolds(k, seed)
+	result := CrossValidationResult{Accuracies: make([]float32, k)}
+	for fold := range folds {
+		trainingData, validationData := data.Fold(folds, fold)
+		fmt.Printf("\nFold %d of %d\n", fold+1, k)
+		ts := trainingData.GenerateTrainingSamples(trainingData.Length())
+		vs := validationData.GenerateTrainingSamples(validationData.Length())
+		network := train(ts, vs)
+		accuracy := network.RunSamples(vs, false)
+		result.Accuracies[fold] = accuracy
+		result.Mean += float64(accuracy)
+	}
+	result.Mean /= float64(k)
+	for _, accuracy := range result.Accuracies {
+		d := float64(accuracy) - result.Mean
+		result.StdDev += d * d
+	}
+	result.StdDev = math.Sqrt(result.StdDev / float64(k-1))
+	fmt.Printf("\nCross-validation accuracy: %f +/- %f\n", result.Mean, result.StdDev)
+	return result
+}
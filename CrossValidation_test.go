@@ -0,0 +1,39 @@
+package main
+
+import (
+	"SimpleNeuralNet/MNISTImport"
+	"testing"
+)
+
+func TestCrossValidate(t *testing.T) {
+	// Arrange
+	data := MNISTImport.ImportData("./test_data/", "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	var nTrained int
+
+	// Act
+	result := CrossValidate(&data, 3, 1, func(ts []MNISTImport.TrainingSample, vs []MNISTImport.TrainingSample) *Network {
+		nTrained++
+		if len(ts)+len(vs) != data.Length() {
+			t.Errorf("Training and validation samples must add up to %d, but are %d and %d", data.Length(), len(ts), len(vs))
+		}
+		network := CreateNetwork([]int{28 * 28, 10, 10})
+		network.InitializeNetworkWeightsAndBiases()
+		network.Train(ts, vs, 1, 0.5, 0, 10, CrossEntropyCostFunction{})
+		return &network
+	})
+
+	// Assert
+	if nTrained != 3 || len(result.Accuracies) != 3 {
+		t.Fatalf("Expected 3 trained networks, but got %d", nTrained)
+	}
+	var mean float64
+	for _, accuracy := range result.Accuracies {
+		mean += float64(accuracy) / 3
+	}
+	if floatEquals(result.Mean, mean, EPSILON) == false {
+		t.Errorf("Mean accuracy must be %f, but is %f", mean, result.Mean)
+	}
+	if result.StdDev < 0 {
+		t.Errorf("Standard deviation must not be negative, but is %f", result.StdDev)
+	}
+}
@@ -0,0 +1,156 @@
+package LinAlg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Weight matrix shapes of the MNIST networks, rows x cols
+var benchmarkShapes = [][2]int{{30, 784}, {100, 784}, {10, 30}, {10, 100}}
+
+func randomVector(size int, rng *rand.Rand) *Vector {
+	return RandomUniformVector(size, -1, 1, rng)
+}
+
+// Like an MNIST image, about 80% of the elements are zero
+func mnistLikeVector(size int, rng *rand.Rand) *Vector {
+	return MakeVector(randomUniform(size, 0, 1, rng)).Hadamard(BernoulliMaskVector(size, 0.2, rng))
+}
+
+func BenchmarkAx(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	for _, shape := range benchmarkShapes {
+		m := randomMatrix(shape[0], shape[1], rng)
+		v := randomVector(shape[1], rng)
+		dst := MakeEmptyVector(shape[0])
+		b.Run(fmt.Sprintf("Ax-%dx%d", shape[0], shape[1]), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.Ax(v)
+			}
+		})
+		b.Run(fmt.Sprintf("MulVecTo-%dx%d", shape[0], shape[1]), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				MulVecTo(dst, m, v)
+			}
+		})
+	}
+}
+
+func BenchmarkTransposeAx(b *testing.B) {
+	rng := rand.New(rand.NewSource(2))
+	for _, shape := range benchmarkShapes {
+		m := randomMatrix(shape[0], shape[1], rng)
+		v := randomVector(shape[0], rng)
+		dst := MakeEmptyVector(shape[1])
+		b.Run(fmt.Sprintf("Transpose-Ax-%dx%d", shape[0], shape[1]), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.Transpose().Ax(v)
+			}
+		})
+		b.Run(fmt.Sprintf("MulTransVecTo-%dx%d", shape[0], shape[1]), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				MulTransVecTo(dst, m, v)
+			}
+		})
+	}
+}
+
+func BenchmarkOuterProduct(b *testing.B) {
+	rng := rand.New(rand.NewSource(3))
+	for _, shape := range benchmarkShapes {
+		v1 := randomVector(shape[0], rng)
+		v2 := randomVector(shape[1], rng)
+		dst := MakeEmptyMatrix(shape[0], shape[1])
+		b.Run(fmt.Sprintf("OuterProduct-%dx%d", shape[0], shape[1]), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				OuterProduct(v1, v2)
+			}
+		})
+		b.Run(fmt.Sprintf("AddOuterProductTo-%dx%d", shape[0], shape[1]), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				AddOuterProductTo(dst, 1, v1, v2)
+			}
+		})
+	}
+}
+
+// First layer kernels for sparse MNIST-like input activations
+func BenchmarkSparseInput(b *testing.B) {
+	rng := rand.New(rand.NewSource(4))
+	for _, rows := range []int{30, 100} {
+		m := randomMatrix(rows, 784, rng)
+		v := mnistLikeVector(784, rng)
+		s := v.ToSparse()
+		delta := randomVector(rows, rng)
+		dst := MakeEmptyVector(rows)
+		dw := MakeEmptyMatrix(rows, 784)
+		b.Run(fmt.Sprintf("MulVecTo-%dx784", rows), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				MulVecTo(dst, m, v)
+			}
+		})
+		b.Run(fmt.Sprintf("MulSparseVecTo-%dx784", rows), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				MulSparseVecTo(dst, m, s)
+			}
+		})
+		b.Run(fmt.Sprintf("AddOuterProductTo-%dx784", rows), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				AddOuterProductTo(dw, 1, delta, v)
+			}
+		})
+		b.Run(fmt.Sprintf("AddSparseOuterProductTo-%dx784", rows), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				AddSparseOuterProductTo(dw, 1, delta, s)
+			}
+		})
+	}
+}
+
+func BenchmarkElementwise(b *testing.B) {
+	rng := rand.New(rand.NewSource(5))
+	sigmoid := func(z float64) float64 {
+		return 1 / (1 + math.Exp(-z))
+	}
+	for _, size := range []int{10, 100, 784} {
+		v1 := randomVector(size, rng)
+		v2 := randomVector(size, rng)
+		dst := MakeEmptyVector(size)
+		b.Run(fmt.Sprintf("Hadamard-%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				v1.Hadamard(v2)
+			}
+		})
+		b.Run(fmt.Sprintf("HadamardTo-%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				HadamardTo(dst, v1, v2)
+			}
+		})
+		b.Run(fmt.Sprintf("FTo-sigmoid-%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				FTo(dst, v1, sigmoid)
+			}
+		})
+		b.Run(fmt.Sprintf("DotProduct-%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				v1.DotProduct(v2)
+			}
+		})
+	}
+}
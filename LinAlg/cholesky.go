@@ -0,0 +1,78 @@
+package LinAlg
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrNotPositiveDefinite = errors.New("LinAlg: Matrix is not positive definite")
+
+// Cholesky decomposition m = L L^T of a symmetric positive definite matrix
+type Cholesky struct {
+	l *Matrix
+}
+
+// Only the lower triangle of m is read
+func FactorizeCholesky(m *Matrix) (*Cholesky, error) {
+	if m.Rows != m.Cols {
+		panic(dimensionError("LinAlg.FactorizeCholesky", "Matrix must be square, but is %dx%d", m.Rows, m.Cols))
+	}
+	n := m.Rows
+	l := MakeEmptyMatrix(n, n)
+	for row := 0; row < n; row++ {
+		lRow := l.data[row*n : (row+1)*n]
+		for col := 0; col <= row; col++ {
+			cRow := l.data[col*n : (col+1)*n]
+			s := m.Get(row, col)
+			for k := 0; k < col; k++ {
+				s -= lRow[k] * cRow[k]
+			}
+			if row == col {
+				if s <= 0 {
+					return nil, ErrNotPositiveDefinite
+				}
+				lRow[col] = math.Sqrt(s)
+			} else {
+				lRow[col] = s / cRow[col]
+			}
+		}
+	}
+	return &Cholesky{l: l}, nil
+}
+
+// Lower triangular factor
+func (f *Cholesky) L() *Matrix {
+	return f.l.Clone()
+}
+
+func (f *Cholesky) Determinant() float64 {
+	d := float64(1)
+	for idx := 0; idx < f.l.Rows; idx++ {
+		d *= f.l.Get(idx, idx)
+	}
+	return d * d
+}
+
+// Returns x with m x = b
+func (f *Cholesky) Solve(b *Vector) *Vector {
+	n := f.l.Rows
+	if b.Size() != n {
+		panic(dimensionError("LinAlg.Cholesky.Solve", "Vector size %d must equal matrix size %d", b.Size(), n))
+	}
+
+	// L y = b, then L^T x = y
+	x := b.Clone()
+	for row := 0; row < n; row++ {
+		for col := 0; col < row; col++ {
+			x.data[row] -= f.l.Get(row, col) * x.data[col]
+		}
+		x.data[row] /= f.l.Get(row, row)
+	}
+	for row := n - 1; row >= 0; row-- {
+		for k := row + 1; k < n; k++ {
+			x.data[row] -= f.l.Get(k, row) * x.data[k]
+		}
+		x.data[row] /= f.l.Get(row, row)
+	}
+	return x
+}
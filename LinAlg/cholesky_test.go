@@ -0,0 +1,40 @@
+package LinAlg
+
+import "testing"
+
+func Test_Cholesky(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 3, []float64{4, 12, -16, 12, 37, -43, -16, -43, 98})
+
+	// Act
+	c, err := FactorizeCholesky(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := c.Solve(MakeVector([]float64{0, 6, 39}))
+
+	// Assert
+	assertMatricesEqual(t, MakeMatrix(3, 3, []float64{2, 0, 0, 6, 1, 0, -8, 5, 3}), c.L())
+	if expected := float64(36); floatEquals(c.Determinant(), expected, 0.0000001) == false {
+		t.Errorf("Determinant must be %f, but is %f", expected, c.Determinant())
+	}
+	b := m.Ax(x)
+	for idx, e := range []float64{0, 6, 39} {
+		if floatEquals(b.Get(idx), e, 0.0000001) == false {
+			t.Errorf("Solution violates m x = b at %d, %f != %f", idx, b.Get(idx), e)
+		}
+	}
+}
+
+func Test_CholeskyNotPositiveDefinite(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 2, []float64{1, 2, 2, 1})
+
+	// Act
+	_, err := FactorizeCholesky(m)
+
+	// Assert
+	if err != ErrNotPositiveDefinite {
+		t.Errorf("Expected ErrNotPositiveDefinite, but got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package LinAlg
+
+import (
+	"math"
+	"sort"
+)
+
+// Eigen-decomposition m = V diag(values) V^T of the symmetric matrix m using
+// the cyclic Jacobi method. Returns the eigenvalues in decreasing order and
+// the corresponding eigenvectors as the columns of V.
+func SymmetricEigen(m *Matrix) (*Vector, *Matrix) {
+	if m.Rows != m.Cols {
+		panic(dimensionError("LinAlg.SymmetricEigen", "Matrix must be square, but is %dx%d", m.Rows, m.Cols))
+	}
+	n := m.Rows
+	a := m.Clone()
+	v := MakeEmptyMatrix(n, n)
+	for idx := 0; idx < n; idx++ {
+		v.Set(idx, idx, 1)
+	}
+
+	for sweep := 0; sweep < 100; sweep++ {
+		norm := a.SumOfSquares()
+		offDiagonal := norm
+		for idx := 0; idx < n; idx++ {
+			offDiagonal -= a.Get(idx, idx) * a.Get(idx, idx)
+		}
+		if offDiagonal <= 1e-22*norm || offDiagonal <= 0 {
+			break
+		}
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := a.Get(p, q)
+				if apq == 0 {
+					continue
+				}
+				// rotation angle that zeroes a_pq
+				theta := (a.Get(q, q) - a.Get(p, p)) / (2 * apq)
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				rotateCols(a, p, q, c, s)
+				rotateRows(a, p, q, c, s)
+				rotateCols(v, p, q, c, s)
+			}
+		}
+	}
+
+	// sort by decreasing eigenvalue
+	order := make([]int, n)
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return a.Get(order[i], order[i]) > a.Get(order[j], order[j])
+	})
+	values := MakeEmptyVector(n)
+	vectors := MakeEmptyMatrix(n, n)
+	for k, idx := range order {
+		values.Set(k, a.Get(idx, idx))
+		vectors.SetCol(k, v.Col(idx))
+	}
+	return values, vectors
+}
+
+// Replaces columns p and q of m by c col_p - s col_q and s col_p + c col_q
+func rotateCols(m *Matrix, p int, q int, c float64, s float64) {
+	for row := 0; row < m.Rows; row++ {
+		mRow := m.data[row*m.Cols : (row+1)*m.Cols]
+		mp, mq := mRow[p], mRow[q]
+		mRow[p] = c*mp - s*mq
+		mRow[q] = s*mp + c*mq
+	}
+}
+
+// Replaces rows p and q of m by c row_p - s row_q and s row_p + c row_q
+func rotateRows(m *Matrix, p int, q int, c float64, s float64) {
+	pRow := m.data[p*m.Cols : (p+1)*m.Cols]
+	qRow := m.data[q*m.Cols : (q+1)*m.Cols]
+	for col := range pRow {
+		mp, mq := pRow[col], qRow[col]
+		pRow[col] = c*mp - s*mq
+		qRow[col] = s*mp + c*mq
+	}
+}
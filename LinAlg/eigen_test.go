@@ -0,0 +1,29 @@
+package LinAlg
+
+import "testing"
+
+func Test_SymmetricEigen(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 3, []float64{4, 1, 2, 1, 3, 0, 2, 0, 5})
+
+	// Act
+	values, vectors := SymmetricEigen(m)
+
+	// Assert
+	for k := 0; k < 3; k++ {
+		v := vectors.Col(k)
+		av := m.Ax(v)
+		for i := 0; i < 3; i++ {
+			if expected := values.Get(k) * v.Get(i); floatEquals(av.Get(i), expected, EPSILON) == false {
+				t.Errorf("Eigenpair %d violates Av = lambda v, %f != %f", k, av.Get(i), expected)
+			}
+		}
+		if k > 0 && values.Get(k) > values.Get(k-1) {
+			t.Errorf("Eigenvalues must decrease, but %f > %f", values.Get(k), values.Get(k-1))
+		}
+	}
+	assertMatricesEqual(t, identity(3), vectors.Transpose().Am(vectors))
+	if expected := float64(12); floatEquals(values.Sum(), expected, EPSILON) == false {
+		t.Errorf("Eigenvalues must sum to the trace %f, but sum to %f", expected, values.Sum())
+	}
+}
@@ -0,0 +1,80 @@
+package LinAlg
+
+// Returns the element-wise product of m and other as a new matrix
+func (m *Matrix) Hadamard(other *Matrix) *Matrix {
+	checkSameShape("LinAlg.Matrix.Hadamard", m, other)
+	return MatrixHadamardTo(MakeEmptyMatrix(m.Rows, m.Cols), m, other)
+}
+
+// Returns the element-wise quotient of m and other as a new matrix
+func (m *Matrix) Divide(other *Matrix) *Matrix {
+	checkSameShape("LinAlg.Matrix.Divide", m, other)
+	return MatrixDivideTo(MakeEmptyMatrix(m.Rows, m.Cols), m, other)
+}
+
+// Element-wise product into dst, which may be m1 or m2
+func MatrixHadamardTo(dst *Matrix, m1 *Matrix, m2 *Matrix) *Matrix {
+	checkSameShape("LinAlg.MatrixHadamardTo", m1, m2)
+	checkSameShape("LinAlg.MatrixHadamardTo", dst, m1)
+	for idx := range dst.data {
+		dst.data[idx] = m1.data[idx] * m2.data[idx]
+	}
+	return dst
+}
+
+// Element-wise quotient into dst, which may be m1 or m2
+func MatrixDivideTo(dst *Matrix, m1 *Matrix, m2 *Matrix) *Matrix {
+	checkSameShape("LinAlg.MatrixDivideTo", m1, m2)
+	checkSameShape("LinAlg.MatrixDivideTo", dst, m1)
+	for idx := range dst.data {
+		dst.data[idx] = m1.data[idx] / m2.data[idx]
+	}
+	return dst
+}
+
+// Adds 'scalar' to each element, in place
+func (m *Matrix) AddScalar(scalar float64) *Matrix {
+	for idx := range m.data {
+		m.data[idx] += scalar
+	}
+	return m
+}
+
+// Adds v to every row of m, in place. v must have m.Cols elements.
+func (m *Matrix) AddRowVector(v *Vector) *Matrix {
+	if v.Size() != m.Cols {
+		panic(dimensionError("LinAlg.Matrix.AddRowVector", "Vector size %d must equal matrix number of columns %d", v.Size(), m.Cols))
+	}
+	for row := 0; row < m.Rows; row++ {
+		r := m.data[row*m.Cols : (row+1)*m.Cols]
+		for col := range r {
+			r[col] += v.data[col]
+		}
+	}
+	return m
+}
+
+// Adds v to every column of m, in place. v must have m.Rows elements, i.e.
+// a bias vector added to a minibatch of weighted inputs stored as columns.
+func (m *Matrix) AddColVector(v *Vector) *Matrix {
+	if v.Size() != m.Rows {
+		panic(dimensionError("LinAlg.Matrix.AddColVector", "Vector size %d must equal matrix number of rows %d", v.Size(), m.Rows))
+	}
+	for row := 0; row < m.Rows; row++ {
+		r := m.data[row*m.Cols : (row+1)*m.Cols]
+		b := v.data[row]
+		for col := range r {
+			r[col] += b
+		}
+	}
+	return m
+}
+
+func checkSameShape(op string, m *Matrix, other *Matrix) {
+	if m.Rows != other.Rows {
+		panic(dimensionError(op, "Matrix number of rows %d and %d must equal", m.Rows, other.Rows))
+	}
+	if m.Cols != other.Cols {
+		panic(dimensionError(op, "Matrix number of columns %d and %d must equal", m.Cols, other.Cols))
+	}
+}
@@ -0,0 +1,44 @@
+package LinAlg
+
+import "testing"
+
+func Test_MatrixHadamardDivide(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 2, []float64{1, 2, 3, 4})
+	other := MakeMatrix(2, 2, []float64{2, 4, 6, 8})
+
+	// Act
+	product := m.Hadamard(other)
+	quotient := m.Divide(other)
+	inPlace := MatrixHadamardTo(other.Clone(), m, other)
+	MatrixDivideTo(inPlace, inPlace, other)
+
+	// Assert
+	assertMatricesEqual(t, MakeMatrix(2, 2, []float64{2, 8, 18, 32}), product)
+	assertMatricesEqual(t, MakeMatrix(2, 2, []float64{0.5, 0.5, 0.5, 0.5}), quotient)
+	assertMatricesEqual(t, MakeMatrix(2, 2, []float64{1, 2, 3, 4}), m)
+	assertMatricesEqual(t, m, inPlace)
+	err := Try(func() { m.Hadamard(MakeEmptyMatrix(2, 3)) })
+	if _, ok := err.(*DimensionError); ok == false {
+		t.Errorf("Different shapes must raise a DimensionError, got %v", err)
+	}
+}
+
+func Test_MatrixBroadcastAdd(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+
+	// Act
+	rows := m.Clone().AddRowVector(MakeVector([]float64{10, 20, 30}))
+	cols := m.Clone().AddColVector(MakeVector([]float64{10, 20}))
+	shifted := m.Clone().AddScalar(-1)
+
+	// Assert
+	assertMatricesEqual(t, MakeMatrix(2, 3, []float64{11, 22, 33, 14, 25, 36}), rows)
+	assertMatricesEqual(t, MakeMatrix(2, 3, []float64{11, 12, 13, 24, 25, 26}), cols)
+	assertMatricesEqual(t, MakeMatrix(2, 3, []float64{0, 1, 2, 3, 4, 5}), shifted)
+	err := Try(func() { m.AddColVector(MakeEmptyVector(3)) })
+	if _, ok := err.(*DimensionError); ok == false {
+		t.Errorf("Wrong vector size must raise a DimensionError, got %v", err)
+	}
+}
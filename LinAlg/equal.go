@@ -0,0 +1,61 @@
+package LinAlg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Number of mismatching elements listed by the diff report of EqualApprox
+const maxReportedMismatches = 5
+
+// Elements a and b are equal if |a - b| <= absTol + relTol max(|a|, |b|)
+func equalApprox(a float64, b float64, absTol float64, relTol float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= absTol+relTol*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// Reports whether v and other have the same size and approximately equal
+// elements. If not, the second result describes the first mismatches.
+func (v *Vector) EqualApprox(other *Vector, absTol float64, relTol float64) (bool, string) {
+	if v.Size() != other.Size() {
+		return false, fmt.Sprintf("Vector sizes %d and %d differ", v.Size(), other.Size())
+	}
+	return diffApprox(v.data, other.data, absTol, relTol, func(idx int) string {
+		return fmt.Sprintf("[%d]", idx)
+	})
+}
+
+// Reports whether m and other have the same shape and approximately equal
+// elements. If not, the second result describes the first mismatches.
+func (m *Matrix) EqualApprox(other *Matrix, absTol float64, relTol float64) (bool, string) {
+	if m.Rows != other.Rows || m.Cols != other.Cols {
+		return false, fmt.Sprintf("Matrix sizes %dx%d and %dx%d differ", m.Rows, m.Cols, other.Rows, other.Cols)
+	}
+	return diffApprox(m.data, other.data, absTol, relTol, func(idx int) string {
+		return fmt.Sprintf("(%d, %d)", idx/m.Cols, idx%m.Cols)
+	})
+}
+
+func diffApprox(a []float64, b []float64, absTol float64, relTol float64, position func(int) string) (bool, string) {
+	var report strings.Builder
+	mismatches := 0
+	for idx := range a {
+		if equalApprox(a[idx], b[idx], absTol, relTol) {
+			continue
+		}
+		if mismatches < maxReportedMismatches {
+			fmt.Fprintf(&report, "%s: %g != %g (difference %g)\n", position(idx), a[idx], b[idx], a[idx]-b[idx])
+		}
+		mismatches++
+	}
+	if mismatches == 0 {
+		return true, ""
+	}
+	if mismatches > maxReportedMismatches {
+		fmt.Fprintf(&report, "... %d more\n", mismatches-maxReportedMismatches)
+	}
+	return false, fmt.Sprintf("%d of %d elements differ\n%s", mismatches, len(a), report.String())
+}
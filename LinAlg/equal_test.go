@@ -0,0 +1,57 @@
+package LinAlg
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_VectorEqualApprox(t *testing.T) {
+	// Arrange
+	v1 := MakeVector([]float64{1, 100, -3})
+	v2 := MakeVector([]float64{1.0000001, 100.5, -3})
+
+	// Act
+	absOk, absDiff := v1.EqualApprox(v2, 0.000001, 0)
+	relOk, _ := v1.EqualApprox(v2, 0.000001, 0.01)
+	sizeOk, sizeDiff := v1.EqualApprox(MakeVector([]float64{1}), 1, 1)
+
+	// Assert
+	if absOk {
+		t.Error("Vectors must differ with absolute tolerance only")
+	}
+	if strings.Contains(absDiff, "[1]") == false || strings.Contains(absDiff, "[0]") {
+		t.Errorf("Diff must only report element 1, but is %q", absDiff)
+	}
+	if relOk == false {
+		t.Error("Vectors must be equal with relative tolerance")
+	}
+	if sizeOk || strings.Contains(sizeDiff, "sizes") == false {
+		t.Errorf("Vectors of different size must differ, diff %q", sizeDiff)
+	}
+}
+
+func Test_MatrixEqualApproxReport(t *testing.T) {
+	// Arrange
+	m1 := MakeEmptyMatrix(3, 4)
+	m2 := MakeEmptyMatrix(3, 4)
+	for idx := range m2.data {
+		m2.data[idx] = 1
+	}
+
+	// Act
+	ok, diff := m1.EqualApprox(m2, 0.5, 0)
+
+	// Assert
+	if ok {
+		t.Fatal("Matrices must differ")
+	}
+	if strings.HasPrefix(diff, "12 of 12 elements differ") == false {
+		t.Errorf("Diff must count the mismatches, but is %q", diff)
+	}
+	if strings.Contains(diff, "(0, 1)") == false || strings.Contains(diff, "(2, 3)") {
+		t.Errorf("Diff must list only the first mismatches, but is %q", diff)
+	}
+	if strings.Contains(diff, "... 7 more") == false {
+		t.Errorf("Diff must report the number of unlisted mismatches, but is %q", diff)
+	}
+}
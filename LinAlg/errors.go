@@ -0,0 +1,140 @@
+package LinAlg
+
+import "fmt"
+
+// Operands of an operation have incompatible sizes. LinAlg operations panic
+// with a *DimensionError, use Try to turn it into an error.
+type DimensionError struct {
+	// operation, i.e. "LinAlg.Matrix.Ax"
+	Op      string
+	Message string
+}
+
+func (e *DimensionError) Error() string {
+	return e.Op + ": " + e.Message
+}
+
+// Element or row/column index outside of a vector or matrix
+type IndexError struct {
+	Op      string
+	Message string
+}
+
+func (e *IndexError) Error() string {
+	return e.Op + ": " + e.Message
+}
+
+func dimensionError(op string, format string, args ...interface{}) *DimensionError {
+	return &DimensionError{Op: op, Message: fmt.Sprintf(format, args...)}
+}
+
+func indexError(op string, format string, args ...interface{}) *IndexError {
+	return &IndexError{Op: op, Message: fmt.Sprintf(format, args...)}
+}
+
+// Runs f and returns the *DimensionError or *IndexError it panics with, i.e.
+//
+//	err := LinAlg.Try(func() { r = m.Ax(v) })
+//
+// Other panics are passed on.
+func Try(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case *DimensionError:
+				err = e
+			case *IndexError:
+				err = e
+			default:
+				panic(r)
+			}
+		}
+	}()
+	f()
+	return nil
+}
+
+//
+// Checked variants, returning an error instead of panicking
+//
+
+func MakeMatrixChecked(rows int, cols int, data []float64) (*Matrix, error) {
+	if err := checkMatrixSize("LinAlg.MakeMatrixChecked", rows, cols, len(data)); err != nil {
+		return nil, err
+	}
+	return &Matrix{Rows: rows, Cols: cols, data: data}, nil
+}
+
+func checkMatrixSize(op string, rows int, cols int, size int) error {
+	if n, ok := shapeSize([]int{rows, cols}); ok == false || n != size {
+		return dimensionError(op, "Matrix data has size %d, but %dx%d expected", size, rows, cols)
+	}
+	return nil
+}
+
+// Returns the number of elements of an array of the given shape, or a
+// *DimensionError for negative dimensions or if the number overflows an int
+func CheckShape(op string, shape ...int) (int, error) {
+	size, ok := shapeSize(shape)
+	if ok == false {
+		return 0, dimensionError(op, "Invalid shape %v", shape)
+	}
+	return size, nil
+}
+
+func (m *Matrix) checkIndex(op string, row int, col int) error {
+	if row < 0 || row >= m.Rows || col < 0 || col >= m.Cols {
+		return indexError(op, "Index (%d, %d) out of range for a %dx%d matrix", row, col, m.Rows, m.Cols)
+	}
+	return nil
+}
+
+func (m *Matrix) GetChecked(row int, col int) (float64, error) {
+	if err := m.checkIndex("LinAlg.Matrix.GetChecked", row, col); err != nil {
+		return 0, err
+	}
+	return m.Get(row, col), nil
+}
+
+func (m *Matrix) SetChecked(row int, col int, value float64) error {
+	if err := m.checkIndex("LinAlg.Matrix.SetChecked", row, col); err != nil {
+		return err
+	}
+	m.Set(row, col, value)
+	return nil
+}
+
+func (v *Vector) GetChecked(index int) (float64, error) {
+	if index < 0 || index >= v.Size() {
+		return 0, indexError("LinAlg.Vector.GetChecked", "Index %d out of range [0, %d)", index, v.Size())
+	}
+	return v.Get(index), nil
+}
+
+func (v *Vector) SetChecked(index int, value float64) error {
+	if index < 0 || index >= v.Size() {
+		return indexError("LinAlg.Vector.SetChecked", "Index %d out of range [0, %d)", index, v.Size())
+	}
+	v.Set(index, value)
+	return nil
+}
+
+func (m *Matrix) AxChecked(v *Vector) (result *Vector, err error) {
+	err = Try(func() { result = m.Ax(v) })
+	return
+}
+
+func (m *Matrix) AmChecked(other *Matrix) (result *Matrix, err error) {
+	err = Try(func() { result = m.Am(other) })
+	return
+}
+
+func AddVectorsChecked(v1 *Vector, v2 *Vector) (result *Vector, err error) {
+	err = Try(func() { result = AddVectors(v1, v2) })
+	return
+}
+
+func SubtractVectorsChecked(v1 *Vector, v2 *Vector) (result *Vector, err error) {
+	err = Try(func() { result = SubtractVectors(v1, v2) })
+	return
+}
@@ -0,0 +1,112 @@
+package LinAlg
+
+import (
+	"SimpleNeuralNet/Utility"
+	"bytes"
+	"testing"
+)
+
+func Test_TryReturnsDimensionError(t *testing.T) {
+	// Arrange
+	m := MakeEmptyMatrix(2, 3)
+	v := MakeEmptyVector(2)
+
+	// Act
+	_, err := m.AxChecked(v)
+
+	// Assert
+	dimensionError, ok := err.(*DimensionError)
+	if ok == false {
+		t.Fatalf("Expected *DimensionError, but got %v", err)
+	}
+	if dimensionError.Op != "LinAlg.Matrix.Ax" {
+		t.Errorf("Unexpected operation %s", dimensionError.Op)
+	}
+	if expected := "LinAlg.Matrix.Ax: Matrix number of columns 3 must equal vector size 2"; err.Error() != expected {
+		t.Errorf("Expected %q, but got %q", expected, err.Error())
+	}
+	if _, err := m.AxChecked(MakeEmptyVector(3)); err != nil {
+		t.Errorf("Compatible sizes must not fail, but got %v", err)
+	}
+}
+
+func Test_TryPassesOnOtherPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Try must pass on panics other than LinAlg errors")
+		}
+	}()
+	Try(func() {
+		panic("other")
+	})
+}
+
+func Test_CheckedAccessors(t *testing.T) {
+	// Arrange
+	m := MakeEmptyMatrix(2, 3)
+	v := MakeEmptyVector(2)
+
+	// Act
+	setErr := m.SetChecked(1, 2, 5)
+	value, getErr := m.GetChecked(1, 2)
+	_, colErr := m.GetChecked(0, 3)
+	_, vErr := v.GetChecked(-1)
+	_, addErr := AddVectorsChecked(v, MakeEmptyVector(3))
+
+	// Assert
+	if setErr != nil || getErr != nil || value != 5 {
+		t.Errorf("Checked access within bounds failed, %v %v %f", setErr, getErr, value)
+	}
+	if _, ok := colErr.(*IndexError); ok == false {
+		t.Errorf("Column out of range must return *IndexError, but got %v", colErr)
+	}
+	if _, ok := vErr.(*IndexError); ok == false {
+		t.Errorf("Negative index must return *IndexError, but got %v", vErr)
+	}
+	if _, ok := addErr.(*DimensionError); ok == false {
+		t.Errorf("Adding vectors of different sizes must return *DimensionError, but got %v", addErr)
+	}
+	if _, err := MakeMatrixChecked(2, 2, []float64{1}); err == nil {
+		t.Error("MakeMatrixChecked must reject data of the wrong size")
+	}
+}
+
+func TestMatrixGobDecodeValidatesSize(t *testing.T) {
+	// Arrange
+	corrupt := &Matrix{Rows: 3, Cols: 3, data: []float64{1, 2, 3, 4}}
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, corrupt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err = Utility.ReadGob(&buf, new(Matrix))
+
+	// Assert
+	if err == nil {
+		t.Error("Decoding a matrix with rows * cols != len(data) must fail")
+	}
+}
+
+func TestMatrixSizeOverflow(t *testing.T) {
+	// Arrange, 4 * 2^62 overflows to 0
+	corrupt := &Matrix{Rows: 4, Cols: 1 << 62, data: []float64{}}
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, corrupt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	decodeErr := Utility.ReadGob(&buf, new(Matrix))
+	_, makeErr := MakeMatrixChecked(1<<32, 1<<32, nil)
+
+	// Assert
+	if _, ok := decodeErr.(*DimensionError); ok == false {
+		t.Errorf("Decoding a 4x2^62 matrix must raise a DimensionError, got %v", decodeErr)
+	}
+	if _, ok := makeErr.(*DimensionError); ok == false {
+		t.Errorf("A 2^32x2^32 matrix must raise a DimensionError, got %v", makeErr)
+	}
+}
@@ -0,0 +1,146 @@
+package LinAlg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Controls how vectors and matrices are rendered as text
+type PrintOptions struct {
+	// 'g', 'f' or 'e', as for strconv.FormatFloat
+	Verb byte
+
+	// digits after the decimal point ('f', 'e') or significant digits ('g')
+	Precision int
+
+	// Rows and columns with more elements than this are elided, showing only
+	// Edge elements at either end. 0 disables elision.
+	Threshold int
+	Edge      int
+}
+
+// Used by String, and by the fmt verbs where no precision is given
+var DefaultPrintOptions = PrintOptions{Verb: 'g', Precision: 6, Threshold: 10, Edge: 3}
+
+func (v *Vector) String() string {
+	return v.Render(DefaultPrintOptions)
+}
+
+func (m *Matrix) String() string {
+	return m.Render(DefaultPrintOptions)
+}
+
+// Implements fmt.Formatter. Supports %v, %s, %f, %e and %g with an optional
+// precision, i.e. %.2f. %+v prints all elements without elision.
+func (v *Vector) Format(f fmt.State, verb rune) {
+	fmt.Fprint(f, v.Render(printOptions(f, verb)))
+}
+
+// Implements fmt.Formatter, like Vector.Format
+func (m *Matrix) Format(f fmt.State, verb rune) {
+	fmt.Fprint(f, m.Render(printOptions(f, verb)))
+}
+
+func printOptions(f fmt.State, verb rune) PrintOptions {
+	options := DefaultPrintOptions
+	switch verb {
+	case 'f', 'e', 'g':
+		options.Verb = byte(verb)
+	}
+	if precision, ok := f.Precision(); ok {
+		options.Precision = precision
+	}
+	if verb == 'v' && f.Flag('+') {
+		options.Threshold = 0
+	}
+	return options
+}
+
+// Renders v as [a b c], with the elements right-aligned
+func (v *Vector) Render(options PrintOptions) string {
+	indices := shownIndices(v.Size(), options)
+	cells := make([]string, len(indices))
+	for k, idx := range indices {
+		cells[k] = formatElement(v.data, idx, options)
+	}
+	width := maxWidth(cells)
+	return "[" + joinCells(cells, width) + "]"
+}
+
+// Renders m in aligned rows, like
+//
+//	[[ 1 -2  3]
+//	 [-4  5  6]]
+func (m *Matrix) Render(options PrintOptions) string {
+	rows := shownIndices(m.Rows, options)
+	cols := shownIndices(m.Cols, options)
+	cells := make([][]string, len(rows))
+	var all []string
+	for r, row := range rows {
+		cells[r] = make([]string, len(cols))
+		for c, col := range cols {
+			if row < 0 {
+				cells[r][c] = "..."
+				continue
+			}
+			cells[r][c] = formatElement(m.data[row*m.Cols:(row+1)*m.Cols], col, options)
+		}
+		all = append(all, cells[r]...)
+	}
+	width := maxWidth(all)
+	var b strings.Builder
+	b.WriteString("[")
+	for r := range cells {
+		if r > 0 {
+			b.WriteString("\n ")
+		}
+		b.WriteString("[" + joinCells(cells[r], width) + "]")
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Indices of the elements to show, -1 marks the elided ones
+func shownIndices(n int, options PrintOptions) []int {
+	var indices []int
+	if options.Threshold > 0 && n > options.Threshold && 2*options.Edge < n {
+		for idx := 0; idx < options.Edge; idx++ {
+			indices = append(indices, idx)
+		}
+		indices = append(indices, -1)
+		for idx := n - options.Edge; idx < n; idx++ {
+			indices = append(indices, idx)
+		}
+		return indices
+	}
+	for idx := 0; idx < n; idx++ {
+		indices = append(indices, idx)
+	}
+	return indices
+}
+
+func formatElement(data []float64, idx int, options PrintOptions) string {
+	if idx < 0 {
+		return "..."
+	}
+	return strconv.FormatFloat(data[idx], options.Verb, options.Precision, 64)
+}
+
+func maxWidth(cells []string) int {
+	width := 0
+	for _, cell := range cells {
+		if len(cell) > width {
+			width = len(cell)
+		}
+	}
+	return width
+}
+
+func joinCells(cells []string, width int) string {
+	padded := make([]string, len(cells))
+	for idx, cell := range cells {
+		padded[idx] = strings.Repeat(" ", width-len(cell)) + cell
+	}
+	return strings.Join(padded, " ")
+}
@@ -0,0 +1,60 @@
+package LinAlg
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_VectorFormat(t *testing.T) {
+	// Arrange
+	v := MakeVector([]float64{1, -2.5, 30})
+
+	// Act, Assert
+	tables := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "[   1 -2.5   30]"},
+		{"%.2f", "[ 1.00 -2.50 30.00]"},
+		{"%s", "[   1 -2.5   30]"},
+	}
+	for _, ts := range tables {
+		if s := fmt.Sprintf(ts.format, v); s != ts.expected {
+			t.Errorf("%s: expected %q, but got %q", ts.format, ts.expected, s)
+		}
+	}
+	if s := v.String(); s != tables[0].expected {
+		t.Errorf("String: expected %q, but got %q", tables[0].expected, s)
+	}
+}
+
+func Test_MatrixFormat(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, -2, 3, -4, 5, 60})
+
+	// Act
+	s := fmt.Sprintf("%.1f", m)
+
+	// Assert
+	if expected := "[[ 1.0 -2.0  3.0]\n [-4.0  5.0 60.0]]"; s != expected {
+		t.Errorf("Expected\n%s\nbut got\n%s", expected, s)
+	}
+}
+
+func Test_MatrixFormatElision(t *testing.T) {
+	// Arrange
+	m := MakeEmptyMatrix(12, 12)
+	options := PrintOptions{Verb: 'f', Precision: 0, Threshold: 4, Edge: 1}
+
+	// Act
+	s := m.Render(options)
+	full := fmt.Sprintf("%+v", m)
+
+	// Assert
+	if expected := "[[  0 ...   0]\n [... ... ...]\n [  0 ...   0]]"; s != expected {
+		t.Errorf("Expected\n%s\nbut got\n%s", expected, s)
+	}
+	if expected := 12*25 + 11*2 + 2; len(full) != expected {
+		t.Errorf("%%+v must not elide, expected %d characters, but got %d", expected, len(full))
+	}
+}
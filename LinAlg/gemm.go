@@ -0,0 +1,82 @@
+package LinAlg
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Block size of the matrix multiplication, chosen so that a block of each
+// operand (3 * 64 * 64 * 8 bytes) fits into the L2 cache
+const gemmBlockSize = 64
+
+func (m *Matrix) Am(other *Matrix) *Matrix {
+	if m.Cols != other.Rows {
+		panic(dimensionError("LinAlg.Matrix.Am", "Matrices of size %dx%d and %dx%d not compatible", m.Rows, m.Cols, other.Rows, other.Cols))
+	}
+	result := MakeEmptyMatrix(m.Rows, other.Cols)
+	gemm(result, m, other, 0, m.Rows)
+	return result
+}
+
+// Like Am, but the rows of the result are computed by 'workers' goroutines,
+// runtime.GOMAXPROCS if workers <= 0
+func (m *Matrix) AmParallel(other *Matrix, workers int) *Matrix {
+	if m.Cols != other.Rows {
+		panic(dimensionError("LinAlg.Matrix.AmParallel", "Matrices of size %dx%d and %dx%d not compatible", m.Rows, m.Cols, other.Rows, other.Cols))
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	result := MakeEmptyMatrix(m.Rows, other.Cols)
+
+	// distribute whole row blocks, so no two goroutines write the same block
+	nBlocks := (m.Rows + gemmBlockSize - 1) / gemmBlockSize
+	if workers > nBlocks {
+		workers = nBlocks
+	}
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		firstBlock := worker * nBlocks / workers
+		lastBlock := (worker + 1) * nBlocks / workers
+		wg.Add(1)
+		go func(rowStart int, rowEnd int) {
+			defer wg.Done()
+			gemm(result, m, other, rowStart, rowEnd)
+		}(firstBlock*gemmBlockSize, minInt(lastBlock*gemmBlockSize, m.Rows))
+	}
+	wg.Wait()
+	return result
+}
+
+func minInt(lhs int, rhs int) int {
+	if lhs < rhs {
+		return lhs
+	}
+	return rhs
+}
+
+// c += a b for the rows [rowStart, rowEnd) of c. Loops over blocks of a, b
+// and c, and within a block in i-k-j order, so that the innermost loop
+// walks contiguously through rows of b and c.
+func gemm(c *Matrix, a *Matrix, b *Matrix, rowStart int, rowEnd int) {
+	n := b.Cols
+	for ii := rowStart; ii < rowEnd; ii += gemmBlockSize {
+		iEnd := minInt(ii+gemmBlockSize, rowEnd)
+		for kk := 0; kk < a.Cols; kk += gemmBlockSize {
+			kEnd := minInt(kk+gemmBlockSize, a.Cols)
+			for jj := 0; jj < n; jj += gemmBlockSize {
+				jEnd := minInt(jj+gemmBlockSize, n)
+				for i := ii; i < iEnd; i++ {
+					cRow := c.data[i*n+jj : i*n+jEnd]
+					for k := kk; k < kEnd; k++ {
+						aik := a.data[i*a.Cols+k]
+						bRow := b.data[k*n+jj : k*n+jEnd]
+						for j := range cRow {
+							cRow[j] += aik * bRow[j]
+						}
+					}
+				}
+			}
+		}
+	}
+}
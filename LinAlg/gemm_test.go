@@ -0,0 +1,116 @@
+package LinAlg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Reference implementation, the triple loop Am used before blocking
+func amNaive(m *Matrix, other *Matrix) *Matrix {
+	result := MakeEmptyMatrix(m.Rows, other.Cols)
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < other.Cols; col++ {
+			var value float64
+			for k := 0; k < m.Cols; k++ {
+				value += m.Get(row, k) * other.Get(k, col)
+			}
+			result.Set(row, col, value)
+		}
+	}
+	return result
+}
+
+func randomMatrix(rows int, cols int, rng *rand.Rand) *Matrix {
+	m := MakeEmptyMatrix(rows, cols)
+	for idx := range m.data {
+		m.data[idx] = rng.Float64()*2 - 1
+	}
+	return m
+}
+
+func assertMatricesEqual(t *testing.T, expected *Matrix, actual *Matrix) {
+	t.Helper()
+	if ok, diff := actual.EqualApprox(expected, 0.000000001, 0); ok == false {
+		t.Fatal(diff)
+	}
+}
+
+func Test_MatrixMatrixMultiplicationNonSquare(t *testing.T) {
+	// Arrange
+	m1 := MakeMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	m2 := MakeMatrix(3, 4, []float64{1, 0, 2, -1, 0, 1, 1, 2, 3, -2, 0, 1})
+
+	// Act
+	r := m1.Am(m2)
+
+	// Assert
+	expected := MakeMatrix(2, 4, []float64{10, -4, 4, 6, 22, -7, 13, 12})
+	assertMatricesEqual(t, expected, r)
+}
+
+func Test_MatrixMatrixMultiplicationBlocked(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, size := range [][]int{{1, 1, 1}, {65, 1, 3}, {100, 70, 130}, {3, 200, 2}, {129, 64, 65}} {
+		// Arrange
+		m1 := randomMatrix(size[0], size[1], rng)
+		m2 := randomMatrix(size[1], size[2], rng)
+
+		// Act
+		r := m1.Am(m2)
+		rp := m1.AmParallel(m2, 3)
+
+		// Assert
+		expected := amNaive(m1, m2)
+		assertMatricesEqual(t, expected, r)
+		assertMatricesEqual(t, expected, rp)
+	}
+}
+
+func BenchmarkAm(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	for _, size := range []int{100, 300, 784} {
+		m1 := randomMatrix(size, size, rng)
+		m2 := randomMatrix(size, size, rng)
+		b.Run(fmt.Sprintf("naive-%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				amNaive(m1, m2)
+			}
+		})
+		b.Run(fmt.Sprintf("blocked-%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m1.Am(m2)
+			}
+		})
+		b.Run(fmt.Sprintf("parallel-%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m1.AmParallel(m2, 0)
+			}
+		})
+	}
+}
+
+func Test_MatrixMatrixMultiplicationPropagatesNaN(t *testing.T) {
+	// Arrange, 0 * Inf is NaN
+	a := MakeMatrix(1, 2, []float64{0, 1})
+	b := MakeMatrix(2, 1, []float64{math.Inf(1), 2})
+
+	// Act
+	c := a.Am(b)
+	ax := a.Ax(MakeVector([]float64{math.Inf(1), 2}))
+	tax := b.TransposeAx(MakeVector([]float64{0, 1}))
+	tax32 := b.ToFloat32().TransposeAx(MakeVector32([]float32{0, 1}))
+	outer := OuterProduct(MakeVector([]float64{0}), MakeVector([]float64{math.Inf(1)}))
+
+	// Assert
+	if math.IsNaN(c.Get(0, 0)) == false || math.IsNaN(ax.Get(0)) == false {
+		t.Errorf("0 * Inf must give NaN, but Am gives %f and Ax gives %f", c.Get(0, 0), ax.Get(0))
+	}
+	if math.IsNaN(tax.Get(0)) == false || math.IsNaN(float64(tax32.Get(0))) == false {
+		t.Errorf("0 * Inf must give NaN, but TransposeAx gives %f and %f in single precision", tax.Get(0), tax32.Get(0))
+	}
+	if math.IsNaN(outer.Get(0, 0)) == false {
+		t.Errorf("0 * Inf must give NaN, but OuterProduct gives %f", outer.Get(0, 0))
+	}
+}
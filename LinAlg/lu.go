@@ -0,0 +1,184 @@
+package LinAlg
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrSingular = errors.New("LinAlg: Matrix is singular")
+
+// LU decomposition with partial pivoting, P m = L U. L (unit lower
+// triangular) and U are stored together in 'lu'.
+type LU struct {
+	lu *Matrix
+
+	// row 'idx' of P m is row pivot[idx] of m
+	pivot []int
+
+	// determinant of P, +1 or -1
+	sign float64
+}
+
+func FactorizeLU(m *Matrix) *LU {
+	if m.Rows != m.Cols {
+		panic(dimensionError("LinAlg.FactorizeLU", "Matrix must be square, but is %dx%d", m.Rows, m.Cols))
+	}
+	n := m.Rows
+	lu := m.Clone()
+	pivot := make([]int, n)
+	for idx := range pivot {
+		pivot[idx] = idx
+	}
+	sign := float64(1)
+	for k := 0; k < n; k++ {
+		// row with the largest element in column k
+		p := k
+		for row := k + 1; row < n; row++ {
+			if math.Abs(lu.data[row*n+k]) > math.Abs(lu.data[p*n+k]) {
+				p = row
+			}
+		}
+		if p != k {
+			swapRows(lu, p, k)
+			pivot[p], pivot[k] = pivot[k], pivot[p]
+			sign = -sign
+		}
+		d := lu.data[k*n+k]
+		if d == 0 {
+			continue
+		}
+		kRow := lu.data[k*n : (k+1)*n]
+		for row := k + 1; row < n; row++ {
+			rRow := lu.data[row*n : (row+1)*n]
+			l := rRow[k] / d
+			rRow[k] = l
+			if l == 0 {
+				continue
+			}
+			for col := k + 1; col < n; col++ {
+				rRow[col] -= l * kRow[col]
+			}
+		}
+	}
+	return &LU{lu: lu, pivot: pivot, sign: sign}
+}
+
+func swapRows(m *Matrix, i int, j int) {
+	iRow := m.data[i*m.Cols : (i+1)*m.Cols]
+	jRow := m.data[j*m.Cols : (j+1)*m.Cols]
+	for col := range iRow {
+		iRow[col], jRow[col] = jRow[col], iRow[col]
+	}
+}
+
+// Unit lower triangular factor
+func (f *LU) L() *Matrix {
+	n := f.lu.Rows
+	l := MakeEmptyMatrix(n, n)
+	for row := 0; row < n; row++ {
+		for col := 0; col < row; col++ {
+			l.Set(row, col, f.lu.Get(row, col))
+		}
+		l.Set(row, row, 1)
+	}
+	return l
+}
+
+// Upper triangular factor
+func (f *LU) U() *Matrix {
+	n := f.lu.Rows
+	u := MakeEmptyMatrix(n, n)
+	for row := 0; row < n; row++ {
+		for col := row; col < n; col++ {
+			u.Set(row, col, f.lu.Get(row, col))
+		}
+	}
+	return u
+}
+
+// Permutation matrix P
+func (f *LU) P() *Matrix {
+	n := f.lu.Rows
+	p := MakeEmptyMatrix(n, n)
+	for row, col := range f.pivot {
+		p.Set(row, col, 1)
+	}
+	return p
+}
+
+func (f *LU) Determinant() float64 {
+	d := f.sign
+	for idx := 0; idx < f.lu.Rows; idx++ {
+		d *= f.lu.Get(idx, idx)
+	}
+	return d
+}
+
+func (f *LU) isSingular() bool {
+	for idx := 0; idx < f.lu.Rows; idx++ {
+		if f.lu.Get(idx, idx) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns x with m x = b
+func (f *LU) Solve(b *Vector) (*Vector, error) {
+	n := f.lu.Rows
+	if b.Size() != n {
+		panic(dimensionError("LinAlg.LU.Solve", "Vector size %d must equal matrix size %d", b.Size(), n))
+	}
+	if f.isSingular() {
+		return nil, ErrSingular
+	}
+	x := MakeEmptyVector(n)
+	for row, p := range f.pivot {
+		x.data[row] = b.data[p]
+	}
+
+	// forward substitution with L, then back substitution with U
+	for row := 0; row < n; row++ {
+		luRow := f.lu.data[row*n : (row+1)*n]
+		for col := 0; col < row; col++ {
+			x.data[row] -= luRow[col] * x.data[col]
+		}
+	}
+	for row := n - 1; row >= 0; row-- {
+		luRow := f.lu.data[row*n : (row+1)*n]
+		for col := row + 1; col < n; col++ {
+			x.data[row] -= luRow[col] * x.data[col]
+		}
+		x.data[row] /= luRow[row]
+	}
+	return x, nil
+}
+
+func (f *LU) Inverse() (*Matrix, error) {
+	n := f.lu.Rows
+	inverse := MakeEmptyMatrix(n, n)
+	e := MakeEmptyVector(n)
+	for col := 0; col < n; col++ {
+		e.data[col] = 1
+		x, err := f.Solve(e)
+		if err != nil {
+			return nil, err
+		}
+		inverse.SetCol(col, x)
+		e.data[col] = 0
+	}
+	return inverse, nil
+}
+
+func (m *Matrix) Determinant() float64 {
+	return FactorizeLU(m).Determinant()
+}
+
+func (m *Matrix) Inverse() (*Matrix, error) {
+	return FactorizeLU(m).Inverse()
+}
+
+// Returns x with m x = b for a square matrix m
+func (m *Matrix) Solve(b *Vector) (*Vector, error) {
+	return FactorizeLU(m).Solve(b)
+}
@@ -0,0 +1,82 @@
+package LinAlg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func identity(n int) *Matrix {
+	m := MakeEmptyMatrix(n, n)
+	for idx := 0; idx < n; idx++ {
+		m.Set(idx, idx, 1)
+	}
+	return m
+}
+
+func Test_LUFactors(t *testing.T) {
+	// Arrange
+	m := randomMatrix(5, 5, rand.New(rand.NewSource(1)))
+
+	// Act
+	lu := FactorizeLU(m)
+
+	// Assert
+	assertMatricesEqual(t, lu.P().Am(m), lu.L().Am(lu.U()))
+}
+
+func Test_LUSolve(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 3, []float64{0, 2, 1, 1, 1, 1, 2, 1, 0})
+	b := MakeVector([]float64{7, 6, 4})
+
+	// Act
+	x, err := m.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	expected := []float64{1, 2, 3}
+	for idx, e := range expected {
+		if floatEquals(x.Get(idx), e, EPSILON) == false {
+			t.Errorf("Solution element %d must be %f, but is %f", idx, e, x.Get(idx))
+		}
+	}
+}
+
+func Test_LUDeterminantAndInverse(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 3, []float64{2, -1, 0, -1, 2, -1, 0, -1, 2})
+
+	// Act
+	d := m.Determinant()
+	inverse, err := m.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if expected := float64(4); floatEquals(d, expected, EPSILON) == false {
+		t.Errorf("Determinant must be %f, but is %f", expected, d)
+	}
+	assertMatricesEqual(t, identity(3), m.Am(inverse))
+	if d := MakeMatrix(2, 2, []float64{0, 1, 1, 0}).Determinant(); floatEquals(d, -1, EPSILON) == false {
+		t.Errorf("Row swap must flip the sign of the determinant, but is %f", d)
+	}
+}
+
+func Test_LUSingular(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 2, []float64{1, 2, 2, 4})
+
+	// Act
+	_, err := m.Inverse()
+
+	// Assert
+	if err != ErrSingular {
+		t.Errorf("Expected ErrSingular, but got %v", err)
+	}
+	if d := m.Determinant(); d != 0 {
+		t.Errorf("Determinant must be 0, but is %f", d)
+	}
+}
@@ -3,7 +3,6 @@ package LinAlg
 import (
 	"bytes"
 	"encoding/gob"
-	"fmt"
 )
 
 type Matrix struct {
@@ -47,12 +46,16 @@ func (m *Matrix) GobDecode(buf []byte) error {
 	if err != nil {
 		return err
 	}
-	return decoder.Decode(&m.data)
+	err = decoder.Decode(&m.data)
+	if err != nil {
+		return err
+	}
+	return checkMatrixSize("LinAlg.Matrix.GobDecode", m.Rows, m.Cols, len(m.data))
 }
 
 func MakeMatrix(rows int, cols int, data []float64) *Matrix {
 	if size := rows * cols; size != len(data) {
-		panic(fmt.Sprintf("LinAlg.Matrix.MakeMatrix: Matrix data has size %d, but %d expected", len(data), size))
+		panic(dimensionError("LinAlg.Matrix.MakeMatrix", "Matrix data has size %d, but %d expected", len(data), size))
 	}
 	return &Matrix{Rows: rows, Cols: cols, data: data}
 }
@@ -89,34 +92,17 @@ func (m *Matrix) Transpose() *Matrix {
 
 func (m *Matrix) Ax(v *Vector) *Vector {
 	if m.Cols != v.Size() {
-		panic(fmt.Sprintf("LinAlg.Matrix.Ax: Matrix number of columns %d must equal vector size %d", m.Cols, v.Size()))
+		panic(dimensionError("LinAlg.Matrix.Ax", "Matrix number of columns %d must equal vector size %d", m.Cols, v.Size()))
 	}
-	result := MakeEmptyVector(m.Rows)
-	for row := 0; row < m.Rows; row++ {
-		var value float64
-		for col := 0; col < m.Cols; col++ {
-			value += m.Get(row, col) * v.Get(col)
-		}
-		result.Set(row, value)
-	}
-	return result
+	return MulVecTo(MakeEmptyVector(m.Rows), m, v)
 }
 
-func (m *Matrix) Am(other *Matrix) *Matrix {
-	if m.Cols != other.Rows {
-		panic(fmt.Sprint("LinAlg.Matrix.Am: Matrices not compatible"))
-	}
-	result := MakeEmptyMatrix(m.Rows, other.Cols)
-	for row := 0; row < m.Rows; row++ {
-		for col := 0; col < m.Cols; col++ {
-			var value float64
-			for k := 0; k < m.Cols; k++ {
-				value += m.Get(row, k) * other.Get(k, col)
-			}
-			result.Set(row, col, value)
-		}
+// Returns m^T v, without forming the transpose
+func (m *Matrix) TransposeAx(v *Vector) *Vector {
+	if m.Rows != v.Size() {
+		panic(dimensionError("LinAlg.Matrix.TransposeAx", "Matrix number of rows %d must equal vector size %d", m.Rows, v.Size()))
 	}
-	return result
+	return MulTransVecTo(MakeEmptyVector(m.Cols), m, v)
 }
 
 func (m *Matrix) Scalar(scalar float64) *Matrix {
@@ -128,10 +114,10 @@ func (m *Matrix) Scalar(scalar float64) *Matrix {
 
 func (m *Matrix) Add(other *Matrix) *Matrix {
 	if m.Rows != other.Rows {
-		panic(fmt.Sprintf("LinAlg.Matrix.Add: Matrix number of rows %d and %d must equal", m.Rows, other.Rows))
+		panic(dimensionError("LinAlg.Matrix.Add", "Matrix number of rows %d and %d must equal", m.Rows, other.Rows))
 	}
 	if m.Cols != other.Cols {
-		panic(fmt.Sprintf("LinAlg.Matrix.Add: Matrix number of columns %d and %d must equal", m.Cols, other.Cols))
+		panic(dimensionError("LinAlg.Matrix.Add", "Matrix number of columns %d and %d must equal", m.Cols, other.Cols))
 	}
 	for row := 0; row < m.Rows; row++ {
 		for col := 0; col < m.Cols; col++ {
@@ -146,10 +132,10 @@ func (m *Matrix) Add(other *Matrix) *Matrix {
 
 func (m *Matrix) Sub(other *Matrix) *Matrix {
 	if m.Rows != other.Rows {
-		panic(fmt.Sprintf("LinAlg.Matrix.Sub: Matrix number of rows %d and %d must equal", m.Rows, other.Rows))
+		panic(dimensionError("LinAlg.Matrix.Sub", "Matrix number of rows %d and %d must equal", m.Rows, other.Rows))
 	}
 	if m.Cols != other.Cols {
-		panic(fmt.Sprintf("LinAlg.Matrix.Sub: Matrix number of columns %d and %d must equal", m.Cols, other.Cols))
+		panic(dimensionError("LinAlg.Matrix.Sub", "Matrix number of columns %d and %d must equal", m.Cols, other.Cols))
 	}
 	for row := 0; row < m.Rows; row++ {
 		for col := 0; col < m.Cols; col++ {
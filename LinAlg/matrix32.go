@@ -0,0 +1,155 @@
+package LinAlg
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// Single precision counterpart of Matrix
+type Matrix32 struct {
+	Rows int
+	Cols int
+	data []float32
+}
+
+//
+// Implement interface 'GobEncoder'
+//
+func (m *Matrix32) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	err := encoder.Encode(m.Rows)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(m.Cols)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(m.data)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+//
+// Implement interface 'GobDecoder'
+//
+func (m *Matrix32) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	err := decoder.Decode(&m.Rows)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&m.Cols)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&m.data)
+	if err != nil {
+		return err
+	}
+	return checkMatrixSize("LinAlg.Matrix32.GobDecode", m.Rows, m.Cols, len(m.data))
+}
+
+func MakeMatrix32(rows int, cols int, data []float32) *Matrix32 {
+	if size := rows * cols; size != len(data) {
+		panic(dimensionError("LinAlg.Matrix32.MakeMatrix32", "Matrix data has size %d, but %d expected", len(data), size))
+	}
+	return &Matrix32{Rows: rows, Cols: cols, data: data}
+}
+
+func MakeEmptyMatrix32(rows int, cols int) *Matrix32 {
+	size := rows * cols
+	return &Matrix32{Rows: rows, Cols: cols, data: make([]float32, size)}
+}
+
+func (m *Matrix) ToFloat32() *Matrix32 {
+	result := MakeEmptyMatrix32(m.Rows, m.Cols)
+	for idx, e := range m.data {
+		result.data[idx] = float32(e)
+	}
+	return result
+}
+
+func (m *Matrix32) ToFloat64() *Matrix {
+	result := MakeEmptyMatrix(m.Rows, m.Cols)
+	for idx, e := range m.data {
+		result.data[idx] = float64(e)
+	}
+	return result
+}
+
+func (m *Matrix32) index(row int, col int) int {
+	return row*m.Cols + col
+}
+
+func (m Matrix32) Set(row int, col int, value float32) {
+	idx := m.index(row, col)
+	m.data[idx] = value
+}
+
+func (m *Matrix32) Get(row int, col int) float32 {
+	idx := m.index(row, col)
+	return m.data[idx]
+}
+
+func (m *Matrix32) Transpose() *Matrix32 {
+	t := MakeEmptyMatrix32(m.Cols, m.Rows)
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			t.Set(col, row, m.Get(row, col))
+		}
+	}
+	return t
+}
+
+func (m *Matrix32) Ax(v *Vector32) *Vector32 {
+	if m.Cols != v.Size() {
+		panic(dimensionError("LinAlg.Matrix32.Ax", "Matrix number of columns %d must equal vector size %d", m.Cols, v.Size()))
+	}
+	return MulVec32To(MakeEmptyVector32(m.Rows), m, v)
+}
+
+// Returns m^T v, without forming the transpose
+func (m *Matrix32) TransposeAx(v *Vector32) *Vector32 {
+	if m.Rows != v.Size() {
+		panic(dimensionError("LinAlg.Matrix32.TransposeAx", "Matrix number of rows %d must equal vector size %d", m.Rows, v.Size()))
+	}
+	return MulTransVec32To(MakeEmptyVector32(m.Cols), m, v)
+}
+
+func (m *Matrix32) Scalar(scalar float32) *Matrix32 {
+	for idx := range m.data {
+		m.data[idx] *= scalar
+	}
+	return m
+}
+
+func (m *Matrix32) Add(other *Matrix32) *Matrix32 {
+	if m.Rows != other.Rows {
+		panic(dimensionError("LinAlg.Matrix32.Add", "Matrix number of rows %d and %d must equal", m.Rows, other.Rows))
+	}
+	if m.Cols != other.Cols {
+		panic(dimensionError("LinAlg.Matrix32.Add", "Matrix number of columns %d and %d must equal", m.Cols, other.Cols))
+	}
+	for idx := range m.data {
+		m.data[idx] += other.data[idx]
+	}
+	return m
+}
+
+func (m *Matrix32) Sub(other *Matrix32) *Matrix32 {
+	if m.Rows != other.Rows {
+		panic(dimensionError("LinAlg.Matrix32.Sub", "Matrix number of rows %d and %d must equal", m.Rows, other.Rows))
+	}
+	if m.Cols != other.Cols {
+		panic(dimensionError("LinAlg.Matrix32.Sub", "Matrix number of columns %d and %d must equal", m.Cols, other.Cols))
+	}
+	for idx := range m.data {
+		m.data[idx] -= other.data[idx]
+	}
+	return m
+}
@@ -0,0 +1,32 @@
+package LinAlg
+
+import "testing"
+
+func Test_Matrix32Ax(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 2, []float64{3, 4, -2, -9, 4, 7})
+	v := MakeVector([]float64{1, -1})
+	w := MakeVector([]float64{1, 2, -1})
+
+	// Act
+	m32 := m.ToFloat32()
+	r := m32.Ax(v.ToFloat32())
+	rt := m32.TransposeAx(w.ToFloat32())
+
+	// Assert
+	expected := m.Ax(v)
+	for idx := 0; idx < 3; idx++ {
+		if floatEquals(float64(r.Get(idx)), expected.Get(idx), EPSILON) == false {
+			t.Errorf("Ax error, %f != %f", expected.Get(idx), r.Get(idx))
+		}
+	}
+	expectedT := m.TransposeAx(w)
+	for idx := 0; idx < 2; idx++ {
+		if floatEquals(float64(rt.Get(idx)), expectedT.Get(idx), EPSILON) == false {
+			t.Errorf("TransposeAx error, %f != %f", expectedT.Get(idx), rt.Get(idx))
+		}
+	}
+	if m64 := m32.ToFloat64(); m64.Get(2, 1) != m.Get(2, 1) {
+		t.Errorf("Round trip error, %f != %f", m.Get(2, 1), m64.Get(2, 1))
+	}
+}
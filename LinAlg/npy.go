@@ -0,0 +1,215 @@
+package LinAlg
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Reading and writing of NumPy's .npy format, see
+// https://numpy.org/doc/stable/reference/generated/numpy.lib.format.html
+
+const npyMagic = "\x93NUMPY"
+
+var (
+	npyDescr        = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortranOrder = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShape        = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// Writes v as a 1-dimensional float64 array
+func (v *Vector) WriteNpy(w io.Writer) error {
+	return writeNpy(w, "<f8", []int{v.Size()}, func(bw *bufio.Writer) error {
+		return binary.Write(bw, binary.LittleEndian, v.data)
+	})
+}
+
+// Writes m as a 2-dimensional float64 array in C order
+func (m *Matrix) WriteNpy(w io.Writer) error {
+	return writeNpy(w, "<f8", []int{m.Rows, m.Cols}, func(bw *bufio.Writer) error {
+		return binary.Write(bw, binary.LittleEndian, m.data)
+	})
+}
+
+// Writes v as a 1-dimensional float32 array
+func (v *Vector32) WriteNpy(w io.Writer) error {
+	return writeNpy(w, "<f4", []int{v.Size()}, func(bw *bufio.Writer) error {
+		return binary.Write(bw, binary.LittleEndian, v.data)
+	})
+}
+
+// Writes m as a 2-dimensional float32 array in C order
+func (m *Matrix32) WriteNpy(w io.Writer) error {
+	return writeNpy(w, "<f4", []int{m.Rows, m.Cols}, func(bw *bufio.Writer) error {
+		return binary.Write(bw, binary.LittleEndian, m.data)
+	})
+}
+
+// Reads a 1-dimensional float32 or float64 array
+func ReadNpyVector(r io.Reader) (*Vector, error) {
+	shape, data, err := readNpy(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 1 {
+		return nil, fmt.Errorf("LinAlg.ReadNpyVector: Expected a 1-dimensional array, but shape is %v", shape)
+	}
+	return MakeVector(data), nil
+}
+
+// Reads a 2-dimensional float32 or float64 array in C or Fortran order
+func ReadNpyMatrix(r io.Reader) (*Matrix, error) {
+	shape, data, err := readNpy(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 2 {
+		return nil, fmt.Errorf("LinAlg.ReadNpyMatrix: Expected a 2-dimensional array, but shape is %v", shape)
+	}
+	return MakeMatrix(shape[0], shape[1], data), nil
+}
+
+func writeNpy(w io.Writer, descr string, shape []int, writeData func(*bufio.Writer) error) error {
+	dims := make([]string, len(shape))
+	for idx, d := range shape {
+		dims[idx] = strconv.Itoa(d)
+	}
+	shapeString := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		shapeString += ","
+	}
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", descr, shapeString)
+
+	// pad with spaces so that the data starts at a multiple of 64 bytes
+	prefix := len(npyMagic) + 2 + 2
+	padding := 64 - (prefix+len(header)+1)%64
+	if padding == 64 {
+		padding = 0
+	}
+	header += strings.Repeat(" ", padding) + "\n"
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(npyMagic)
+	bw.Write([]byte{1, 0})
+	binary.Write(bw, binary.LittleEndian, uint16(len(header)))
+	bw.WriteString(header)
+	if err := writeData(bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// Returns the shape and the elements in C order
+func readNpy(r io.Reader) ([]int, []float64, error) {
+	br := bufio.NewReader(r)
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(br, prefix); err != nil {
+		return nil, nil, err
+	}
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return nil, nil, fmt.Errorf("LinAlg: Not a .npy file")
+	}
+	var headerLength uint32
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var length uint16
+		if err := binary.Read(br, binary.LittleEndian, &length); err != nil {
+			return nil, nil, err
+		}
+		headerLength = uint32(length)
+	case 2, 3:
+		if err := binary.Read(br, binary.LittleEndian, &headerLength); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, fmt.Errorf("LinAlg: Unsupported .npy version %d", major)
+	}
+	header := make([]byte, headerLength)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, nil, err
+	}
+
+	descr := npyDescr.FindSubmatch(header)
+	fortranOrder := npyFortranOrder.FindSubmatch(header)
+	shapeMatch := npyShape.FindSubmatch(header)
+	if descr == nil || fortranOrder == nil || shapeMatch == nil {
+		return nil, nil, fmt.Errorf("LinAlg: Malformed .npy header %q", header)
+	}
+	var shape []int
+	size := 1
+	for _, d := range strings.Split(string(shapeMatch[1]), ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 0 {
+			return nil, nil, fmt.Errorf("LinAlg: Malformed .npy shape %q", shapeMatch[1])
+		}
+		if n != 0 && size > math.MaxInt/n {
+			return nil, nil, fmt.Errorf("LinAlg: .npy shape %q is too large", shapeMatch[1])
+		}
+		shape = append(shape, n)
+		size *= n
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	dtype := string(descr[1])
+	if strings.HasPrefix(dtype, ">") {
+		order = binary.BigEndian
+	}
+	var itemSize int
+	switch strings.TrimLeft(dtype, "<>=|") {
+	case "f8":
+		itemSize = 8
+	case "f4":
+		itemSize = 4
+	default:
+		return nil, nil, fmt.Errorf("LinAlg: Unsupported .npy dtype %s, only float32 and float64 are supported", dtype)
+	}
+	if size > math.MaxInt/itemSize {
+		return nil, nil, fmt.Errorf("LinAlg: .npy shape %q is too large", shapeMatch[1])
+	}
+	// Read the payload before allocating the elements, so that the buffer only
+	// grows as far as the stream actually holds data
+	payload, err := io.ReadAll(io.LimitReader(br, int64(size*itemSize)))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(payload) != size*itemSize {
+		return nil, nil, fmt.Errorf("LinAlg: .npy shape %q needs %d bytes of data, but only %d are left", shapeMatch[1], size*itemSize, len(payload))
+	}
+	data := make([]float64, size)
+	for idx := range data {
+		if itemSize == 8 {
+			data[idx] = math.Float64frombits(order.Uint64(payload[idx*8:]))
+		} else {
+			data[idx] = float64(math.Float32frombits(order.Uint32(payload[idx*4:])))
+		}
+	}
+	if string(fortranOrder[1]) == "True" {
+		if len(shape) > 2 {
+			return nil, nil, fmt.Errorf("LinAlg: Fortran order is only supported for up to 2 dimensions")
+		}
+		if len(shape) == 2 {
+			data = fortranToC(data, shape[0], shape[1])
+		}
+	}
+	return shape, data, nil
+}
+
+// Reorders the elements of a rows x cols array from column-major to row-major
+func fortranToC(data []float64, rows int, cols int) []float64 {
+	result := make([]float64, len(data))
+	for col := 0; col < cols; col++ {
+		for row := 0; row < rows; row++ {
+			result[row*cols+col] = data[col*rows+row]
+		}
+	}
+	return result
+}
@@ -0,0 +1,144 @@
+package LinAlg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// .npy file with a version 1.0 header, as written by numpy.save
+func npyFile(header string, data interface{}, order binary.ByteOrder) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("\x93NUMPY\x01\x00")
+	padded := header + strings.Repeat(" ", 64-(10+len(header)+1)%64) + "\n"
+	binary.Write(&buf, binary.LittleEndian, uint16(len(padded)))
+	buf.WriteString(padded)
+	binary.Write(&buf, order, data)
+	return buf.Bytes()
+}
+
+func Test_NpyMatrixRoundTrip(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, -2, 3.5, 4, 5, 6})
+	var buf bytes.Buffer
+
+	// Act
+	err := m.WriteNpy(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := buf.String()[10:strings.Index(buf.String(), "\n")]
+	r, err := ReadNpyMatrix(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if expected := "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }"; strings.TrimRight(header, " ") != expected {
+		t.Errorf("Expected header %q, but got %q", expected, header)
+	}
+	if (10+len(header)+1)%64 != 0 {
+		t.Errorf("Data must start at a multiple of 64 bytes")
+	}
+	assertMatricesEqual(t, m, r)
+}
+
+func Test_NpyVectorRoundTrip(t *testing.T) {
+	// Arrange
+	v := MakeVector([]float64{1, -2, 3.5})
+	var buf bytes.Buffer
+
+	// Act
+	err := v.WriteNpy(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	isVector := strings.Contains(buf.String(), "'shape': (3,)")
+	r, err := ReadNpyVector(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if isVector == false {
+		t.Error("Vector must be written as 1-dimensional array")
+	}
+	if ok, diff := r.EqualApprox(v, 0, 0); ok == false {
+		t.Error(diff)
+	}
+	if _, err := ReadNpyMatrix(bytes.NewReader(npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (1,), }", []float64{1}, binary.LittleEndian))); err == nil {
+		t.Error("Reading a vector as matrix must fail")
+	}
+}
+
+func Test_NpyFloat32(t *testing.T) {
+	// Arrange
+	m := MakeMatrix32(2, 2, []float32{1.5, -2, 3, 0.25})
+	var buf bytes.Buffer
+
+	// Act
+	err := m.WriteNpy(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ReadNpyMatrix(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	assertMatricesEqual(t, m.ToFloat64(), r)
+}
+
+func Test_NpyFortranOrderBigEndian(t *testing.T) {
+	// Arrange, the columns of [[1 2 3] [4 5 6]]
+	file := npyFile("{'descr': '>f4', 'fortran_order': True, 'shape': (2, 3), }", []float32{1, 4, 2, 5, 3, 6}, binary.BigEndian)
+
+	// Act
+	r, err := ReadNpyMatrix(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	assertMatricesEqual(t, MakeMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6}), r)
+}
+
+func Test_NpyUnsupportedDtype(t *testing.T) {
+	// Arrange
+	file := npyFile("{'descr': '<i8', 'fortran_order': False, 'shape': (1,), }", []int64{1}, binary.LittleEndian)
+
+	// Act
+	_, err := ReadNpyVector(bytes.NewReader(file))
+
+	// Assert
+	if err == nil {
+		t.Error("Integer arrays must be rejected")
+	}
+}
+
+func Test_NpyMalformedShape(t *testing.T) {
+	// Arrange
+	headers := []string{
+		// the element count overflows
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (4611686018427387904, 4), }",
+		// the byte count overflows
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (4611686018427387904, 1), }",
+		// does not overflow, but there is far less data
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (1000000000, 1000), }",
+		"{'descr': '<f4', 'fortran_order': False, 'shape': (2, 3), }",
+	}
+
+	for _, header := range headers {
+		file := npyFile(header, []float32{1, 2}, binary.LittleEndian)
+
+		// Act
+		m, err := ReadNpyMatrix(bytes.NewReader(file))
+
+		// Assert
+		if err == nil {
+			t.Errorf("Header %s must be rejected, but got a %dx%d matrix", header, m.Rows, m.Cols)
+		}
+	}
+}
@@ -1,40 +1,121 @@
 package LinAlg
 
-import "fmt"
-
 func AddVectors(v1 *Vector, v2 *Vector) *Vector {
 	if v1.Size() != v2.Size() {
-		panic(fmt.Sprintf("LinAlg.AddVectors: Vector sizes %d and %d must be the same", v1.Size(), v2.Size()))
-	}
-	result := MakeEmptyVector(v1.Size())
-	for idx := range result.data {
-		e1 := v1.data[idx]
-		e2 := v2.data[idx]
-		result.data[idx] = e1 + e2
+		panic(dimensionError("LinAlg.AddVectors", "Vector sizes %d and %d must be the same", v1.Size(), v2.Size()))
 	}
-	return result
+	return AddVectorsTo(MakeEmptyVector(v1.Size()), v1, v2)
 }
 
 func SubtractVectors(v1 *Vector, v2 *Vector) *Vector {
 	if v1.Size() != v2.Size() {
-		panic(fmt.Sprintf("LinAlg.SubVectors: Vector sizes %d and %d must be the same", v1.Size(), v2.Size()))
-	}
-	result := MakeEmptyVector(v1.Size())
-	for idx := range result.data {
-		e1 := v1.data[idx]
-		e2 := v2.data[idx]
-		result.data[idx] = e1 - e2
+		panic(dimensionError("LinAlg.SubVectors", "Vector sizes %d and %d must be the same", v1.Size(), v2.Size()))
 	}
-	return result
+	return SubtractVectorsTo(MakeEmptyVector(v1.Size()), v1, v2)
 }
 
 func OuterProduct(v1 *Vector, v2 *Vector) *Matrix {
-	m := MakeEmptyMatrix(v1.Size(), v2.Size())
-	for row := 0; row < v1.Size(); row++ {
-		for col := 0; col < v2.Size(); col++ {
-			value := v1.Get(row) * v2.Get(col)
-			m.Set(row, col, value)
+	return AddOuterProductTo(MakeEmptyMatrix(v1.Size(), v2.Size()), 1, v1, v2)
+}
+
+//
+// The ...To variants write the result into 'dst' and return it, so they do
+// not allocate. 'dst' may be one of the operands unless stated otherwise.
+//
+
+func AddVectorsTo(dst *Vector, v1 *Vector, v2 *Vector) *Vector {
+	if v1.Size() != v2.Size() || dst.Size() != v1.Size() {
+		panic(dimensionError("LinAlg.AddVectorsTo", "Vector sizes %d, %d and %d must be the same", dst.Size(), v1.Size(), v2.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = v1.data[idx] + v2.data[idx]
+	}
+	return dst
+}
+
+func SubtractVectorsTo(dst *Vector, v1 *Vector, v2 *Vector) *Vector {
+	if v1.Size() != v2.Size() || dst.Size() != v1.Size() {
+		panic(dimensionError("LinAlg.SubtractVectorsTo", "Vector sizes %d, %d and %d must be the same", dst.Size(), v1.Size(), v2.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = v1.data[idx] - v2.data[idx]
+	}
+	return dst
+}
+
+func HadamardTo(dst *Vector, v1 *Vector, v2 *Vector) *Vector {
+	if v1.Size() != v2.Size() || dst.Size() != v1.Size() {
+		panic(dimensionError("LinAlg.HadamardTo", "Vector sizes %d, %d and %d must be the same", dst.Size(), v1.Size(), v2.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = v1.data[idx] * v2.data[idx]
+	}
+	return dst
+}
+
+func FTo(dst *Vector, v *Vector, f func(float64) float64) *Vector {
+	if dst.Size() != v.Size() {
+		panic(dimensionError("LinAlg.FTo", "Vector sizes %d and %d must be the same", dst.Size(), v.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = f(v.data[idx])
+	}
+	return dst
+}
+
+// dst = m v, 'dst' must not be 'v'
+func MulVecTo(dst *Vector, m *Matrix, v *Vector) *Vector {
+	if m.Cols != v.Size() {
+		panic(dimensionError("LinAlg.MulVecTo", "Matrix number of columns %d must equal vector size %d", m.Cols, v.Size()))
+	}
+	if m.Rows != dst.Size() {
+		panic(dimensionError("LinAlg.MulVecTo", "Matrix number of rows %d must equal vector size %d", m.Rows, dst.Size()))
+	}
+	for row := 0; row < m.Rows; row++ {
+		var value float64
+		mRow := m.data[row*m.Cols : (row+1)*m.Cols]
+		for col, e := range mRow {
+			value += e * v.data[col]
+		}
+		dst.data[row] = value
+	}
+	return dst
+}
+
+// dst = m^T v, without forming the transpose. 'dst' must not be 'v'.
+func MulTransVecTo(dst *Vector, m *Matrix, v *Vector) *Vector {
+	if m.Rows != v.Size() {
+		panic(dimensionError("LinAlg.MulTransVecTo", "Matrix number of rows %d must equal vector size %d", m.Rows, v.Size()))
+	}
+	if m.Cols != dst.Size() {
+		panic(dimensionError("LinAlg.MulTransVecTo", "Matrix number of columns %d must equal vector size %d", m.Cols, dst.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = 0
+	}
+
+	// walk m row by row, accumulating v_row * (row of m)
+	for row := 0; row < m.Rows; row++ {
+		vr := v.data[row]
+		mRow := m.data[row*m.Cols : (row+1)*m.Cols]
+		for col, e := range mRow {
+			dst.data[col] += vr * e
+		}
+	}
+	return dst
+}
+
+// Rank-1 update dst += alpha v1 v2^T
+func AddOuterProductTo(dst *Matrix, alpha float64, v1 *Vector, v2 *Vector) *Matrix {
+	if dst.Rows != v1.Size() || dst.Cols != v2.Size() {
+		panic(dimensionError("LinAlg.AddOuterProductTo", "Matrix of size %dx%d does not match vector sizes %d and %d", dst.Rows, dst.Cols, v1.Size(), v2.Size()))
+	}
+	for row := 0; row < dst.Rows; row++ {
+		a := alpha * v1.data[row]
+		dRow := dst.data[row*dst.Cols : (row+1)*dst.Cols]
+		for col, e := range v2.data {
+			dRow[col] += a * e
 		}
 	}
-	return m
+	return dst
 }
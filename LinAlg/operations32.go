@@ -0,0 +1,75 @@
+package LinAlg
+
+//
+// Single precision counterparts of the ...To operations
+//
+
+func AddVectors32To(dst *Vector32, v1 *Vector32, v2 *Vector32) *Vector32 {
+	if v1.Size() != v2.Size() || dst.Size() != v1.Size() {
+		panic(dimensionError("LinAlg.AddVectors32To", "Vector sizes %d, %d and %d must be the same", dst.Size(), v1.Size(), v2.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = v1.data[idx] + v2.data[idx]
+	}
+	return dst
+}
+
+func Hadamard32To(dst *Vector32, v1 *Vector32, v2 *Vector32) *Vector32 {
+	if v1.Size() != v2.Size() || dst.Size() != v1.Size() {
+		panic(dimensionError("LinAlg.Hadamard32To", "Vector sizes %d, %d and %d must be the same", dst.Size(), v1.Size(), v2.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = v1.data[idx] * v2.data[idx]
+	}
+	return dst
+}
+
+func F32To(dst *Vector32, v *Vector32, f func(float32) float32) *Vector32 {
+	if dst.Size() != v.Size() {
+		panic(dimensionError("LinAlg.F32To", "Vector sizes %d and %d must be the same", dst.Size(), v.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = f(v.data[idx])
+	}
+	return dst
+}
+
+// dst = m v, 'dst' must not be 'v'
+func MulVec32To(dst *Vector32, m *Matrix32, v *Vector32) *Vector32 {
+	if m.Cols != v.Size() {
+		panic(dimensionError("LinAlg.MulVec32To", "Matrix number of columns %d must equal vector size %d", m.Cols, v.Size()))
+	}
+	if m.Rows != dst.Size() {
+		panic(dimensionError("LinAlg.MulVec32To", "Matrix number of rows %d must equal vector size %d", m.Rows, dst.Size()))
+	}
+	for row := 0; row < m.Rows; row++ {
+		var value float32
+		mRow := m.data[row*m.Cols : (row+1)*m.Cols]
+		for col, e := range mRow {
+			value += e * v.data[col]
+		}
+		dst.data[row] = value
+	}
+	return dst
+}
+
+// dst = m^T v, without forming the transpose. 'dst' must not be 'v'.
+func MulTransVec32To(dst *Vector32, m *Matrix32, v *Vector32) *Vector32 {
+	if m.Rows != v.Size() {
+		panic(dimensionError("LinAlg.MulTransVec32To", "Matrix number of rows %d must equal vector size %d", m.Rows, v.Size()))
+	}
+	if m.Cols != dst.Size() {
+		panic(dimensionError("LinAlg.MulTransVec32To", "Matrix number of columns %d must equal vector size %d", m.Cols, dst.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = 0
+	}
+	for row := 0; row < m.Rows; row++ {
+		vr := v.data[row]
+		mRow := m.data[row*m.Cols : (row+1)*m.Cols]
+		for col, e := range mRow {
+			dst.data[col] += vr * e
+		}
+	}
+	return dst
+}
@@ -46,3 +46,98 @@ func Test_SubtractVectors(t *testing.T) {
 		t.Error("Vector subtraction error")
 	}
 }
+
+func Test_MulVecTo(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	v := MakeVector([]float64{1, 0, -1})
+	dst := MakeEmptyVector(2)
+
+	// Act
+	r := MulVecTo(dst, m, v)
+
+	// Assert
+	if r != dst {
+		t.Error("MulVecTo must return dst")
+	}
+	if expected := float64(-2); floatEquals(dst.Get(0), expected, EPSILON) == false {
+		t.Errorf("MulVecTo error, %f != %f", expected, dst.Get(0))
+	}
+	if expected := float64(-2); floatEquals(dst.Get(1), expected, EPSILON) == false {
+		t.Errorf("MulVecTo error, %f != %f", expected, dst.Get(1))
+	}
+}
+
+func Test_MulTransVecTo(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	v := MakeVector([]float64{2, -1})
+	dst := MakeVector([]float64{7, 7, 7})
+
+	// Act
+	MulTransVecTo(dst, m, v)
+
+	// Assert
+	expected := m.Transpose().Ax(v)
+	for idx := 0; idx < 3; idx++ {
+		if floatEquals(dst.Get(idx), expected.Get(idx), EPSILON) == false {
+			t.Errorf("MulTransVecTo error, %f != %f", expected.Get(idx), dst.Get(idx))
+		}
+	}
+}
+
+func Test_AddOuterProductTo(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 2, []float64{1, 1, 1, 1})
+	v1 := MakeVector([]float64{1, 2})
+	v2 := MakeVector([]float64{3, 4})
+
+	// Act
+	AddOuterProductTo(m, 0.5, v1, v2)
+
+	// Assert
+	for idx, expected := range []float64{2.5, 3, 4, 5} {
+		if floatEquals(m.data[idx], expected, EPSILON) == false {
+			t.Errorf("AddOuterProductTo error, %f != %f", expected, m.data[idx])
+		}
+	}
+}
+
+func Test_InPlaceVectorOperations(t *testing.T) {
+	// Arrange
+	v1 := MakeVector([]float64{1, 2})
+	v2 := MakeVector([]float64{3, 4})
+
+	// Act
+	HadamardTo(v1, v1, v2)
+	AddVectorsTo(v2, v1, v2)
+	FTo(v1, v1, func(x float64) float64 { return -x })
+
+	// Assert
+	if v1.Get(0) != -3 || v1.Get(1) != -8 {
+		t.Errorf("Unexpected result %v", v1.data)
+	}
+	if v2.Get(0) != 6 || v2.Get(1) != 12 {
+		t.Errorf("Unexpected result %v", v2.data)
+	}
+}
+
+func Test_InPlaceOperationsDoNotAllocate(t *testing.T) {
+	// Arrange
+	m := MakeEmptyMatrix(30, 784)
+	x := MakeEmptyVector(784)
+	y := MakeEmptyVector(30)
+
+	// Act
+	allocs := testing.AllocsPerRun(10, func() {
+		MulVecTo(y, m, x)
+		MulTransVecTo(x, m, y)
+		AddOuterProductTo(m, 1, y, x)
+		SubtractVectorsTo(y, y, y)
+	})
+
+	// Assert
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, but got %f", allocs)
+	}
+}
@@ -0,0 +1,125 @@
+package LinAlg
+
+import "math"
+
+// Householder QR decomposition m = Q R of a matrix with at least as many rows
+// as columns. Q has orthonormal columns, R is upper triangular.
+type QR struct {
+	// Householder vectors below the diagonal, R above it
+	qr *Matrix
+
+	// diagonal of R
+	rDiag []float64
+}
+
+func FactorizeQR(m *Matrix) *QR {
+	if m.Rows < m.Cols {
+		panic(dimensionError("LinAlg.FactorizeQR", "Matrix must have at least as many rows as columns, but is %dx%d", m.Rows, m.Cols))
+	}
+	qr := m.Clone()
+	rows, cols := m.Rows, m.Cols
+	rDiag := make([]float64, cols)
+	for k := 0; k < cols; k++ {
+		var norm float64
+		for row := k; row < rows; row++ {
+			norm = math.Hypot(norm, qr.Get(row, k))
+		}
+		if norm != 0 {
+			// reflect column k onto -sign(x_k) |x| e_k
+			if qr.Get(k, k) < 0 {
+				norm = -norm
+			}
+			for row := k; row < rows; row++ {
+				qr.Set(row, k, qr.Get(row, k)/norm)
+			}
+			qr.Set(k, k, qr.Get(k, k)+1)
+
+			// apply the reflection to the remaining columns
+			for col := k + 1; col < cols; col++ {
+				var s float64
+				for row := k; row < rows; row++ {
+					s += qr.Get(row, k) * qr.Get(row, col)
+				}
+				s = -s / qr.Get(k, k)
+				for row := k; row < rows; row++ {
+					qr.Set(row, col, qr.Get(row, col)+s*qr.Get(row, k))
+				}
+			}
+		}
+		rDiag[k] = -norm
+	}
+	return &QR{qr: qr, rDiag: rDiag}
+}
+
+// Upper triangular factor, Cols x Cols
+func (f *QR) R() *Matrix {
+	n := f.qr.Cols
+	r := MakeEmptyMatrix(n, n)
+	for row := 0; row < n; row++ {
+		r.Set(row, row, f.rDiag[row])
+		for col := row + 1; col < n; col++ {
+			r.Set(row, col, f.qr.Get(row, col))
+		}
+	}
+	return r
+}
+
+// Factor with orthonormal columns, Rows x Cols
+func (f *QR) Q() *Matrix {
+	rows, cols := f.qr.Rows, f.qr.Cols
+	q := MakeEmptyMatrix(rows, cols)
+	for k := cols - 1; k >= 0; k-- {
+		q.Set(k, k, 1)
+		for col := k; col < cols; col++ {
+			if f.qr.Get(k, k) == 0 {
+				continue
+			}
+			var s float64
+			for row := k; row < rows; row++ {
+				s += f.qr.Get(row, k) * q.Get(row, col)
+			}
+			s = -s / f.qr.Get(k, k)
+			for row := k; row < rows; row++ {
+				q.Set(row, col, q.Get(row, col)+s*f.qr.Get(row, k))
+			}
+		}
+	}
+	return q
+}
+
+// Returns the least squares solution x minimizing |m x - b|
+func (f *QR) Solve(b *Vector) (*Vector, error) {
+	rows, cols := f.qr.Rows, f.qr.Cols
+	if b.Size() != rows {
+		panic(dimensionError("LinAlg.QR.Solve", "Vector size %d must equal matrix number of rows %d", b.Size(), rows))
+	}
+	for _, d := range f.rDiag {
+		if d == 0 {
+			return nil, ErrSingular
+		}
+	}
+
+	// y = Q^T b
+	y := b.Clone()
+	for k := 0; k < cols; k++ {
+		var s float64
+		for row := k; row < rows; row++ {
+			s += f.qr.Get(row, k) * y.data[row]
+		}
+		s = -s / f.qr.Get(k, k)
+		for row := k; row < rows; row++ {
+			y.data[row] += s * f.qr.Get(row, k)
+		}
+	}
+
+	// back substitution with R
+	x := MakeEmptyVector(cols)
+	for row := cols - 1; row >= 0; row-- {
+		value := y.data[row]
+		for col := row + 1; col < cols; col++ {
+			value -= f.qr.Get(row, col) * x.data[col]
+		}
+		x.data[row] = value / f.rDiag[row]
+	}
+	return x, nil
+}
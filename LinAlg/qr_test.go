@@ -0,0 +1,44 @@
+package LinAlg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func Test_QRFactors(t *testing.T) {
+	// Arrange
+	m := randomMatrix(6, 4, rand.New(rand.NewSource(2)))
+
+	// Act
+	qr := FactorizeQR(m)
+	q := qr.Q()
+	r := qr.R()
+
+	// Assert
+	assertMatricesEqual(t, m, q.Am(r))
+	assertMatricesEqual(t, identity(4), q.Transpose().Am(q))
+	for row := 0; row < 4; row++ {
+		for col := 0; col < row; col++ {
+			if r.Get(row, col) != 0 {
+				t.Errorf("R must be upper triangular, but (%d, %d) is %f", row, col, r.Get(row, col))
+			}
+		}
+	}
+}
+
+func Test_QRLeastSquares(t *testing.T) {
+	// Arrange, fit y = 1 + 2x to points on the line
+	m := MakeMatrix(4, 2, []float64{1, 0, 1, 1, 1, 2, 1, 3})
+	b := MakeVector([]float64{1, 3, 5, 7})
+
+	// Act
+	x, err := FactorizeQR(m).Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if floatEquals(x.Get(0), 1, EPSILON) == false || floatEquals(x.Get(1), 2, EPSILON) == false {
+		t.Errorf("Least squares solution must be (1, 2), but is (%f, %f)", x.Get(0), x.Get(1))
+	}
+}
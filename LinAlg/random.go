@@ -0,0 +1,86 @@
+package LinAlg
+
+import "math/rand"
+
+// Random constructors. All take an explicit source, so results are
+// reproducible for a seeded rand.Rand.
+
+// Elements uniformly distributed in [low, high)
+func RandomUniformVector(size int, low float64, high float64, rng *rand.Rand) *Vector {
+	return MakeVector(randomUniform(size, low, high, rng))
+}
+
+// Elements uniformly distributed in [low, high)
+func RandomUniformMatrix(rows int, cols int, low float64, high float64, rng *rand.Rand) *Matrix {
+	return MakeMatrix(rows, cols, randomUniform(rows*cols, low, high, rng))
+}
+
+// Normally distributed elements
+func RandomNormalVector(size int, mean float64, stdDev float64, rng *rand.Rand) *Vector {
+	return MakeVector(randomNormal(size, mean, stdDev, rng))
+}
+
+// Normally distributed elements
+func RandomNormalMatrix(rows int, cols int, mean float64, stdDev float64, rng *rand.Rand) *Matrix {
+	return MakeMatrix(rows, cols, randomNormal(rows*cols, mean, stdDev, rng))
+}
+
+// Normally distributed elements, redrawn if more than two standard
+// deviations from the mean
+func TruncatedNormalVector(size int, mean float64, stdDev float64, rng *rand.Rand) *Vector {
+	return MakeVector(truncatedNormal(size, mean, stdDev, rng))
+}
+
+// Normally distributed elements, redrawn if more than two standard
+// deviations from the mean
+func TruncatedNormalMatrix(rows int, cols int, mean float64, stdDev float64, rng *rand.Rand) *Matrix {
+	return MakeMatrix(rows, cols, truncatedNormal(rows*cols, mean, stdDev, rng))
+}
+
+// Elements are 1 with probability p and 0 otherwise, i.e. a dropout mask
+func BernoulliMaskVector(size int, p float64, rng *rand.Rand) *Vector {
+	return MakeVector(bernoulliMask(size, p, rng))
+}
+
+// Elements are 1 with probability p and 0 otherwise
+func BernoulliMaskMatrix(rows int, cols int, p float64, rng *rand.Rand) *Matrix {
+	return MakeMatrix(rows, cols, bernoulliMask(rows*cols, p, rng))
+}
+
+func randomUniform(size int, low float64, high float64, rng *rand.Rand) []float64 {
+	data := make([]float64, size)
+	for idx := range data {
+		data[idx] = low + (high-low)*rng.Float64()
+	}
+	return data
+}
+
+func randomNormal(size int, mean float64, stdDev float64, rng *rand.Rand) []float64 {
+	data := make([]float64, size)
+	for idx := range data {
+		data[idx] = mean + stdDev*rng.NormFloat64()
+	}
+	return data
+}
+
+func truncatedNormal(size int, mean float64, stdDev float64, rng *rand.Rand) []float64 {
+	data := make([]float64, size)
+	for idx := range data {
+		z := rng.NormFloat64()
+		for z < -2 || z > 2 {
+			z = rng.NormFloat64()
+		}
+		data[idx] = mean + stdDev*z
+	}
+	return data
+}
+
+func bernoulliMask(size int, p float64, rng *rand.Rand) []float64 {
+	data := make([]float64, size)
+	for idx := range data {
+		if rng.Float64() < p {
+			data[idx] = 1
+		}
+	}
+	return data
+}
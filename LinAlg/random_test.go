@@ -0,0 +1,82 @@
+package LinAlg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func Test_RandomUniform(t *testing.T) {
+	// Arrange
+	rng := rand.New(rand.NewSource(1))
+
+	// Act
+	m := RandomUniformMatrix(100, 100, -2, 3, rng)
+
+	// Assert
+	if m.Min() < -2 || m.Max() >= 3 {
+		t.Errorf("Elements must be in [-2, 3), but range is [%f, %f]", m.Min(), m.Max())
+	}
+	if floatEquals(m.Mean(), 0.5, 0.05) == false {
+		t.Errorf("Mean must be about 0.5, but is %f", m.Mean())
+	}
+	v1 := RandomUniformVector(5, 0, 1, rand.New(rand.NewSource(7)))
+	v2 := RandomUniformVector(5, 0, 1, rand.New(rand.NewSource(7)))
+	if ok, _ := v1.EqualApprox(v2, 0, 0); ok == false {
+		t.Error("Same seed must give the same elements")
+	}
+}
+
+func Test_RandomNormal(t *testing.T) {
+	// Arrange
+	rng := rand.New(rand.NewSource(2))
+
+	// Act
+	v := RandomNormalVector(20000, 1, 2, rng)
+
+	// Assert
+	if floatEquals(v.Mean(), 1, 0.05) == false {
+		t.Errorf("Mean must be about 1, but is %f", v.Mean())
+	}
+	if stdDev := math.Sqrt(v.Variance()); floatEquals(stdDev, 2, 0.05) == false {
+		t.Errorf("Standard deviation must be about 2, but is %f", stdDev)
+	}
+}
+
+func Test_TruncatedNormal(t *testing.T) {
+	// Arrange
+	rng := rand.New(rand.NewSource(3))
+
+	// Act
+	m := TruncatedNormalMatrix(100, 100, -1, 0.5, rng)
+
+	// Assert
+	if m.Min() < -2 || m.Max() > 0 {
+		t.Errorf("Elements must be within two standard deviations, but range is [%f, %f]", m.Min(), m.Max())
+	}
+	if floatEquals(m.Mean(), -1, 0.02) == false {
+		t.Errorf("Mean must be about -1, but is %f", m.Mean())
+	}
+}
+
+func Test_BernoulliMask(t *testing.T) {
+	// Arrange
+	rng := rand.New(rand.NewSource(4))
+
+	// Act
+	v := BernoulliMaskVector(10000, 0.3, rng)
+	m := BernoulliMaskMatrix(2, 3, 1, rng)
+
+	// Assert
+	for idx := 0; idx < v.Size(); idx++ {
+		if e := v.Get(idx); e != 0 && e != 1 {
+			t.Fatalf("Mask elements must be 0 or 1, but element %d is %f", idx, e)
+		}
+	}
+	if floatEquals(v.Mean(), 0.3, 0.02) == false {
+		t.Errorf("Fraction of ones must be about 0.3, but is %f", v.Mean())
+	}
+	if m.Sum() != 6 {
+		t.Errorf("Mask with p = 1 must be all ones, but sums to %f", m.Sum())
+	}
+}
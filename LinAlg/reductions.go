@@ -0,0 +1,234 @@
+package LinAlg
+
+import "math"
+
+func (v *Vector) Sum() float64 {
+	return sum(v.data)
+}
+
+func (v *Vector) Mean() float64 {
+	return mean(v.data)
+}
+
+// Population variance, i.e. divided by the number of elements
+func (v *Vector) Variance() float64 {
+	return variance(v.data)
+}
+
+func (v *Vector) Max() float64 {
+	return v.data[argMax(v.data, "LinAlg.Vector.Max")]
+}
+
+func (v *Vector) Min() float64 {
+	return v.data[argMin(v.data, "LinAlg.Vector.Min")]
+}
+
+// Index of the largest element, the first one if there are several
+func (v *Vector) ArgMax() int {
+	return argMax(v.data, "LinAlg.Vector.ArgMax")
+}
+
+// Index of the smallest element, the first one if there are several
+func (v *Vector) ArgMin() int {
+	return argMin(v.data, "LinAlg.Vector.ArgMin")
+}
+
+func (v *Vector) L1Norm() float64 {
+	return l1Norm(v.data)
+}
+
+// Sum of the squared elements, the squared Euklidean norm
+func (v *Vector) SumOfSquares() float64 {
+	return sumOfSquares(v.data)
+}
+
+func (m *Matrix) Sum() float64 {
+	return sum(m.data)
+}
+
+func (m *Matrix) Mean() float64 {
+	return mean(m.data)
+}
+
+// Population variance of all elements
+func (m *Matrix) Variance() float64 {
+	return variance(m.data)
+}
+
+func (m *Matrix) Max() float64 {
+	return m.data[argMax(m.data, "LinAlg.Matrix.Max")]
+}
+
+func (m *Matrix) Min() float64 {
+	return m.data[argMin(m.data, "LinAlg.Matrix.Min")]
+}
+
+// Row and column of the largest element, the first one in row-major order
+// if there are several
+func (m *Matrix) ArgMax() (int, int) {
+	idx := argMax(m.data, "LinAlg.Matrix.ArgMax")
+	return idx / m.Cols, idx % m.Cols
+}
+
+// Row and column of the smallest element, the first one in row-major order
+// if there are several
+func (m *Matrix) ArgMin() (int, int) {
+	idx := argMin(m.data, "LinAlg.Matrix.ArgMin")
+	return idx / m.Cols, idx % m.Cols
+}
+
+// Sum of the absolute values of all elements
+func (m *Matrix) L1Norm() float64 {
+	return l1Norm(m.data)
+}
+
+// Sum of the squared elements, the squared Frobenius norm
+func (m *Matrix) SumOfSquares() float64 {
+	return sumOfSquares(m.data)
+}
+
+func (m *Matrix) FrobeniusNorm() float64 {
+	return math.Sqrt(m.SumOfSquares())
+}
+
+// Applies 'f' to each row, returning one value per row
+func (m *Matrix) ReduceRows(f func(*Vector) float64) *Vector {
+	result := MakeEmptyVector(m.Rows)
+	for row := range result.data {
+		result.data[row] = f(m.RowView(row))
+	}
+	return result
+}
+
+// Applies 'f' to each column, returning one value per column
+func (m *Matrix) ReduceCols(f func(*Vector) float64) *Vector {
+	result := MakeEmptyVector(m.Cols)
+	for col := range result.data {
+		result.data[col] = f(m.Col(col))
+	}
+	return result
+}
+
+func (m *Matrix) RowSums() *Vector {
+	return m.ReduceRows((*Vector).Sum)
+}
+
+func (m *Matrix) ColSums() *Vector {
+	// accumulate row by row to walk the data in order
+	result := MakeEmptyVector(m.Cols)
+	for row := 0; row < m.Rows; row++ {
+		result.Add(m.RowView(row))
+	}
+	return result
+}
+
+func (m *Matrix) RowMeans() *Vector {
+	return m.ReduceRows((*Vector).Mean)
+}
+
+func (m *Matrix) ColMeans() *Vector {
+	result := m.ColSums()
+	if m.Rows > 0 {
+		result.Scalar(1 / float64(m.Rows))
+	}
+	return result
+}
+
+func (m *Matrix) RowMax() *Vector {
+	return m.ReduceRows((*Vector).Max)
+}
+
+func (m *Matrix) ColMax() *Vector {
+	return m.ReduceCols((*Vector).Max)
+}
+
+func (m *Matrix) RowMin() *Vector {
+	return m.ReduceRows((*Vector).Min)
+}
+
+func (m *Matrix) ColMin() *Vector {
+	return m.ReduceCols((*Vector).Min)
+}
+
+// Returns a new matrix with 'f' applied to each element
+func (m *Matrix) F(f func(float64) float64) *Matrix {
+	return m.Clone().Apply(f)
+}
+
+// Applies 'f' to each element in place
+func (m *Matrix) Apply(f func(float64) float64) *Matrix {
+	for idx, e := range m.data {
+		m.data[idx] = f(e)
+	}
+	return m
+}
+
+func sum(data []float64) float64 {
+	var s float64
+	for _, e := range data {
+		s += e
+	}
+	return s
+}
+
+func mean(data []float64) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	return sum(data) / float64(len(data))
+}
+
+func variance(data []float64) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	mu := mean(data)
+	var s float64
+	for _, e := range data {
+		d := e - mu
+		s += d * d
+	}
+	return s / float64(len(data))
+}
+
+func l1Norm(data []float64) float64 {
+	var s float64
+	for _, e := range data {
+		s += math.Abs(e)
+	}
+	return s
+}
+
+func sumOfSquares(data []float64) float64 {
+	var s float64
+	for _, e := range data {
+		s += e * e
+	}
+	return s
+}
+
+func argMax(data []float64, caller string) int {
+	if len(data) == 0 {
+		panic(dimensionError(caller, "No elements"))
+	}
+	index := 0
+	for idx, e := range data {
+		if e > data[index] {
+			index = idx
+		}
+	}
+	return index
+}
+
+func argMin(data []float64, caller string) int {
+	if len(data) == 0 {
+		panic(dimensionError(caller, "No elements"))
+	}
+	index := 0
+	for idx, e := range data {
+		if e < data[index] {
+			index = idx
+		}
+	}
+	return index
+}
@@ -0,0 +1,126 @@
+package LinAlg
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_VectorReductions(t *testing.T) {
+	// Arrange
+	v := MakeVector([]float64{2, -3, 5, 5, -3})
+
+	// Act, Assert
+	tables := []struct {
+		name     string
+		value    float64
+		expected float64
+	}{
+		{"Sum", v.Sum(), 6},
+		{"Mean", v.Mean(), 1.2},
+		{"Variance", v.Variance(), 12.96},
+		{"Max", v.Max(), 5},
+		{"Min", v.Min(), -3},
+		{"L1Norm", v.L1Norm(), 18},
+		{"SumOfSquares", v.SumOfSquares(), 72},
+	}
+	for _, ts := range tables {
+		if floatEquals(ts.value, ts.expected, EPSILON) == false {
+			t.Errorf("%s: expected %f, but is %f", ts.name, ts.expected, ts.value)
+		}
+	}
+	if v.ArgMax() != 2 {
+		t.Errorf("ArgMax must be the first maximum 2, but is %d", v.ArgMax())
+	}
+	if v.ArgMin() != 1 {
+		t.Errorf("ArgMin must be the first minimum 1, but is %d", v.ArgMin())
+	}
+}
+
+func Test_ArgMaxOfEmptyVectorPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("ArgMax of an empty vector must panic")
+		}
+	}()
+	MakeEmptyVector(0).ArgMax()
+}
+
+func Test_MatrixReductions(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, -2, 3, -4, 5, 6})
+
+	// Act, Assert
+	tables := []struct {
+		name     string
+		value    float64
+		expected float64
+	}{
+		{"Sum", m.Sum(), 9},
+		{"Mean", m.Mean(), 1.5},
+		{"Variance", m.Variance(), 91.0/6 - 2.25},
+		{"Max", m.Max(), 6},
+		{"Min", m.Min(), -4},
+		{"L1Norm", m.L1Norm(), 21},
+		{"FrobeniusNorm", m.FrobeniusNorm(), math.Sqrt(91)},
+	}
+	for _, ts := range tables {
+		if floatEquals(ts.value, ts.expected, EPSILON) == false {
+			t.Errorf("%s: expected %f, but is %f", ts.name, ts.expected, ts.value)
+		}
+	}
+	if row, col := m.ArgMax(); row != 1 || col != 2 {
+		t.Errorf("ArgMax must be (1, 2), but is (%d, %d)", row, col)
+	}
+	if row, col := m.ArgMin(); row != 1 || col != 0 {
+		t.Errorf("ArgMin must be (1, 0), but is (%d, %d)", row, col)
+	}
+}
+
+func Test_RowAndColumnReductions(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, -2, 3, -4, 5, 6})
+
+	// Act, Assert
+	tables := []struct {
+		name     string
+		value    *Vector
+		expected []float64
+	}{
+		{"RowSums", m.RowSums(), []float64{2, 7}},
+		{"ColSums", m.ColSums(), []float64{-3, 3, 9}},
+		{"RowMeans", m.RowMeans(), []float64{2.0 / 3, 7.0 / 3}},
+		{"ColMeans", m.ColMeans(), []float64{-1.5, 1.5, 4.5}},
+		{"RowMax", m.RowMax(), []float64{3, 6}},
+		{"ColMax", m.ColMax(), []float64{1, 5, 6}},
+		{"RowMin", m.RowMin(), []float64{-2, -4}},
+		{"ColMin", m.ColMin(), []float64{-4, -2, 3}},
+		{"ReduceRows", m.ReduceRows((*Vector).L1Norm), []float64{6, 15}},
+	}
+	for _, ts := range tables {
+		if ts.value.Size() != len(ts.expected) {
+			t.Errorf("%s: expected size %d, but is %d", ts.name, len(ts.expected), ts.value.Size())
+			continue
+		}
+		for idx, expected := range ts.expected {
+			if floatEquals(ts.value.Get(idx), expected, EPSILON) == false {
+				t.Errorf("%s, element %d: expected %f, but is %f", ts.name, idx, expected, ts.value.Get(idx))
+			}
+		}
+	}
+}
+
+func Test_MatrixApply(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 2, []float64{1, -2, 3, -4})
+	square := func(x float64) float64 {
+		return x * x
+	}
+
+	// Act
+	r := m.F(square)
+	m.Apply(math.Abs)
+
+	// Assert
+	assertMatricesEqual(t, MakeMatrix(2, 2, []float64{1, 4, 9, 16}), r)
+	assertMatricesEqual(t, MakeMatrix(2, 2, []float64{1, 2, 3, 4}), m)
+}
@@ -0,0 +1,135 @@
+package LinAlg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Random matrix with roughly the given fraction of zero elements
+func randomSparseMatrix(rows int, cols int, sparsity float64, rng *rand.Rand) *Matrix {
+	m := randomMatrix(rows, cols, rng)
+	for idx := range m.data {
+		if rng.Float64() < sparsity {
+			m.data[idx] = 0
+		}
+	}
+	return m
+}
+
+func Test_SparseVectorConversion(t *testing.T) {
+	// Arrange
+	v := MakeVector([]float64{0, 1.5, 0, 0, -2})
+
+	// Act
+	s := v.ToSparse()
+	d := s.ToDense()
+
+	// Assert
+	if s.NonZeros() != 2 {
+		t.Errorf("Sparse vector must have 2 non-zero elements, but has %d", s.NonZeros())
+	}
+	for idx := 0; idx < v.Size(); idx++ {
+		if s.Get(idx) != v.Get(idx) || d.Get(idx) != v.Get(idx) {
+			t.Errorf("Element %d must be %f, but is %f and %f", idx, v.Get(idx), s.Get(idx), d.Get(idx))
+		}
+	}
+	if expected := float64(-4.5); floatEquals(s.DotProduct(MakeVector([]float64{1, 1, 1, 1, 3})), expected, EPSILON) == false {
+		t.Errorf("Dot product must be %f", expected)
+	}
+}
+
+func Test_SparseMatrixConversion(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 3, []float64{0, 2, 0, 0, 0, 0, 1, 0, -3})
+
+	// Act
+	s := m.ToSparse()
+
+	// Assert
+	if s.NonZeros() != 3 {
+		t.Errorf("Sparse matrix must have 3 non-zero elements, but has %d", s.NonZeros())
+	}
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			if s.Get(row, col) != m.Get(row, col) {
+				t.Errorf("Element (%d, %d) must be %f, but is %f", row, col, m.Get(row, col), s.Get(row, col))
+			}
+		}
+	}
+	assertMatricesEqual(t, m, s.ToDense())
+	expected := MakeSparseMatrix(3, 3, []int{0, 1, 1, 3}, []int{1, 0, 2}, []float64{2, 1, -3})
+	assertMatricesEqual(t, m, expected.ToDense())
+}
+
+func Test_SparseMatrixAx(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	m := randomSparseMatrix(7, 5, 0.7, rng)
+	v := randomMatrix(1, 5, rng)
+	w := randomMatrix(1, 7, rng)
+	x := MakeVector(v.data)
+	y := MakeVector(w.data)
+
+	s := m.ToSparse()
+
+	// Assert
+	assertMatricesEqual(t, MakeMatrix(7, 1, m.Ax(x).data), MakeMatrix(7, 1, s.Ax(x).data))
+	assertMatricesEqual(t, MakeMatrix(5, 1, m.TransposeAx(y).data), MakeMatrix(5, 1, s.TransposeAx(y).data))
+}
+
+func Test_SparseMatrixAddOuterProduct(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, 0, 2, 0, 3, 0})
+	s := m.ToSparse()
+	v1 := MakeVector([]float64{1, 2})
+	v2 := MakeVector([]float64{1, 1, 1})
+
+	// Act
+	s.AddOuterProduct(0.5, v1, v2)
+
+	// Assert
+	expected := MakeMatrix(2, 3, []float64{1.5, 0, 2.5, 0, 4, 0})
+	assertMatricesEqual(t, expected, s.ToDense())
+}
+
+func Test_MulSparseVecTo(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	m := randomMatrix(6, 10, rng)
+	v := MakeVector(randomSparseMatrix(1, 10, 0.8, rng).data)
+
+	r := MulSparseVecTo(MakeEmptyVector(6), m, v.ToSparse())
+
+	// Assert
+	assertMatricesEqual(t, MakeMatrix(6, 1, m.Ax(v).data), MakeMatrix(6, 1, r.data))
+}
+
+func Test_AddSparseOuterProductTo(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	v1 := MakeVector(randomMatrix(1, 4, rng).data)
+	v2 := MakeVector(randomSparseMatrix(1, 6, 0.6, rng).data)
+	expected := AddOuterProductTo(randomMatrix(4, 6, rand.New(rand.NewSource(4))), 2, v1, v2)
+
+	r := AddSparseOuterProductTo(randomMatrix(4, 6, rand.New(rand.NewSource(4))), 2, v1, v2.ToSparse())
+
+	// Assert
+	assertMatricesEqual(t, expected, r)
+}
+
+func Test_MakeSparseMatrixRejectsInconsistentArrays(t *testing.T) {
+	cases := []struct {
+		rows   int
+		rowPtr []int
+	}{
+		// decreasing row pointers, 5 is beyond the values
+		{2, []int{0, 5, 2}},
+		{-1, []int{}},
+	}
+	for _, c := range cases {
+		// Act
+		err := Try(func() { MakeSparseMatrix(c.rows, 3, c.rowPtr, []int{0, 1}, []float64{1, 2}) })
+
+		// Assert
+		if _, ok := err.(*DimensionError); ok == false {
+			t.Errorf("Row pointers %v for %d rows must raise a DimensionError, got %v", c.rowPtr, c.rows, err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package LinAlg
+
+// Matrix in compressed sparse row (CSR) format, i.e. for pruned weights. The
+// non-zero elements of row i are values[rowPtr[i]:rowPtr[i+1]], in the
+// columns colIdx[rowPtr[i]:rowPtr[i+1]], which are strictly increasing.
+type SparseMatrix struct {
+	Rows   int
+	Cols   int
+	rowPtr []int
+	colIdx []int
+	values []float64
+}
+
+func MakeSparseMatrix(rows int, cols int, rowPtr []int, colIdx []int, values []float64) *SparseMatrix {
+	if rows < 0 || cols < 0 || len(rowPtr) != rows+1 || rowPtr[0] != 0 || rowPtr[rows] != len(values) || len(colIdx) != len(values) {
+		panic(dimensionError("LinAlg.MakeSparseMatrix", "Inconsistent CSR arrays for a %dx%d matrix", rows, cols))
+	}
+	// with rowPtr[0] = 0 and rowPtr[rows] = len(values), this keeps all row
+	// pointers within the values
+	for row := 0; row < rows; row++ {
+		if rowPtr[row] > rowPtr[row+1] {
+			panic(dimensionError("LinAlg.MakeSparseMatrix", "Row pointers must not decrease, row %d", row))
+		}
+	}
+	for row := 0; row < rows; row++ {
+		for k := rowPtr[row]; k < rowPtr[row+1]; k++ {
+			if colIdx[k] < 0 || colIdx[k] >= cols || (k > rowPtr[row] && colIdx[k] <= colIdx[k-1]) {
+				panic(dimensionError("LinAlg.MakeSparseMatrix", "Column indices of row %d must be increasing and less than %d", row, cols))
+			}
+		}
+	}
+	return &SparseMatrix{Rows: rows, Cols: cols, rowPtr: rowPtr, colIdx: colIdx, values: values}
+}
+
+// Keeps the non-zero elements of m
+func (m *Matrix) ToSparse() *SparseMatrix {
+	result := &SparseMatrix{Rows: m.Rows, Cols: m.Cols, rowPtr: make([]int, m.Rows+1)}
+	for row := 0; row < m.Rows; row++ {
+		mRow := m.data[row*m.Cols : (row+1)*m.Cols]
+		for col, e := range mRow {
+			if e != 0 {
+				result.colIdx = append(result.colIdx, col)
+				result.values = append(result.values, e)
+			}
+		}
+		result.rowPtr[row+1] = len(result.values)
+	}
+	return result
+}
+
+func (m *SparseMatrix) ToDense() *Matrix {
+	result := MakeEmptyMatrix(m.Rows, m.Cols)
+	for row := 0; row < m.Rows; row++ {
+		for k := m.rowPtr[row]; k < m.rowPtr[row+1]; k++ {
+			result.data[row*m.Cols+m.colIdx[k]] = m.values[k]
+		}
+	}
+	return result
+}
+
+// Number of stored elements
+func (m *SparseMatrix) NonZeros() int {
+	return len(m.values)
+}
+
+func (m *SparseMatrix) Get(row int, col int) float64 {
+	if row < 0 || row >= m.Rows || col < 0 || col >= m.Cols {
+		panic(indexError("LinAlg.SparseMatrix.Get", "Index (%d, %d) out of range for a %dx%d matrix", row, col, m.Rows, m.Cols))
+	}
+	start, end := m.rowPtr[row], m.rowPtr[row+1]
+	k := start + searchInts(m.colIdx[start:end], col)
+	if k < end && m.colIdx[k] == col {
+		return m.values[k]
+	}
+	return 0
+}
+
+func (m *SparseMatrix) Ax(v *Vector) *Vector {
+	if m.Cols != v.Size() {
+		panic(dimensionError("LinAlg.SparseMatrix.Ax", "Matrix number of columns %d must equal vector size %d", m.Cols, v.Size()))
+	}
+	return SparseMulVecTo(MakeEmptyVector(m.Rows), m, v)
+}
+
+// Returns m^T v, without forming the transpose
+func (m *SparseMatrix) TransposeAx(v *Vector) *Vector {
+	if m.Rows != v.Size() {
+		panic(dimensionError("LinAlg.SparseMatrix.TransposeAx", "Matrix number of rows %d must equal vector size %d", m.Rows, v.Size()))
+	}
+	return SparseMulTransVecTo(MakeEmptyVector(m.Cols), m, v)
+}
+
+// m += alpha v1 v2^T, restricted to the stored elements of m, so that pruned
+// weights stay zero
+func (m *SparseMatrix) AddOuterProduct(alpha float64, v1 *Vector, v2 *Vector) *SparseMatrix {
+	if m.Rows != v1.Size() || m.Cols != v2.Size() {
+		panic(dimensionError("LinAlg.SparseMatrix.AddOuterProduct", "Matrix of size %dx%d does not match vector sizes %d and %d", m.Rows, m.Cols, v1.Size(), v2.Size()))
+	}
+	for row := 0; row < m.Rows; row++ {
+		a := alpha * v1.data[row]
+		if a == 0 {
+			continue
+		}
+		for k := m.rowPtr[row]; k < m.rowPtr[row+1]; k++ {
+			m.values[k] += a * v2.data[m.colIdx[k]]
+		}
+	}
+	return m
+}
+
+// dst = m v, 'dst' must not be 'v'
+func SparseMulVecTo(dst *Vector, m *SparseMatrix, v *Vector) *Vector {
+	if m.Cols != v.Size() {
+		panic(dimensionError("LinAlg.SparseMulVecTo", "Matrix number of columns %d must equal vector size %d", m.Cols, v.Size()))
+	}
+	if m.Rows != dst.Size() {
+		panic(dimensionError("LinAlg.SparseMulVecTo", "Matrix number of rows %d must equal vector size %d", m.Rows, dst.Size()))
+	}
+	for row := 0; row < m.Rows; row++ {
+		var value float64
+		for k := m.rowPtr[row]; k < m.rowPtr[row+1]; k++ {
+			value += m.values[k] * v.data[m.colIdx[k]]
+		}
+		dst.data[row] = value
+	}
+	return dst
+}
+
+// dst = m^T v, without forming the transpose. 'dst' must not be 'v'.
+func SparseMulTransVecTo(dst *Vector, m *SparseMatrix, v *Vector) *Vector {
+	if m.Rows != v.Size() {
+		panic(dimensionError("LinAlg.SparseMulTransVecTo", "Matrix number of rows %d must equal vector size %d", m.Rows, v.Size()))
+	}
+	if m.Cols != dst.Size() {
+		panic(dimensionError("LinAlg.SparseMulTransVecTo", "Matrix number of columns %d must equal vector size %d", m.Cols, dst.Size()))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = 0
+	}
+	for row := 0; row < m.Rows; row++ {
+		vr := v.data[row]
+		if vr == 0 {
+			continue
+		}
+		for k := m.rowPtr[row]; k < m.rowPtr[row+1]; k++ {
+			dst.data[m.colIdx[k]] += vr * m.values[k]
+		}
+	}
+	return dst
+}
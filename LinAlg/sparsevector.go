@@ -0,0 +1,135 @@
+package LinAlg
+
+// Vector storing only its non-zero elements, i.e. MNIST input activations.
+// The indices are strictly increasing.
+type SparseVector struct {
+	size    int
+	indices []int
+	values  []float64
+}
+
+func MakeSparseVector(size int, indices []int, values []float64) *SparseVector {
+	if len(indices) != len(values) {
+		panic(dimensionError("LinAlg.MakeSparseVector", "%d indices, but %d values", len(indices), len(values)))
+	}
+	for k, idx := range indices {
+		if idx < 0 || idx >= size || (k > 0 && idx <= indices[k-1]) {
+			panic(dimensionError("LinAlg.MakeSparseVector", "Indices must be increasing and less than %d", size))
+		}
+	}
+	return &SparseVector{size: size, indices: indices, values: values}
+}
+
+// Keeps the non-zero elements of v
+func (v *Vector) ToSparse() *SparseVector {
+	result := &SparseVector{size: v.Size()}
+	for idx, e := range v.data {
+		if e != 0 {
+			result.indices = append(result.indices, idx)
+			result.values = append(result.values, e)
+		}
+	}
+	return result
+}
+
+func (v *SparseVector) ToDense() *Vector {
+	return v.ToDenseTo(MakeEmptyVector(v.size))
+}
+
+// Scatters v into 'dst', overwriting all of its elements
+func (v *SparseVector) ToDenseTo(dst *Vector) *Vector {
+	if dst.Size() != v.size {
+		panic(dimensionError("LinAlg.SparseVector.ToDenseTo", "Vector sizes %d and %d must be the same", dst.Size(), v.size))
+	}
+	for idx := range dst.data {
+		dst.data[idx] = 0
+	}
+	for k, idx := range v.indices {
+		dst.data[idx] = v.values[k]
+	}
+	return dst
+}
+
+func (v *SparseVector) Size() int {
+	return v.size
+}
+
+// Number of stored elements
+func (v *SparseVector) NonZeros() int {
+	return len(v.indices)
+}
+
+func (v *SparseVector) Get(index int) float64 {
+	if index < 0 || index >= v.size {
+		panic(indexError("LinAlg.SparseVector.Get", "Index %d out of range [0, %d)", index, v.size))
+	}
+	k := searchInts(v.indices, index)
+	if k < len(v.indices) && v.indices[k] == index {
+		return v.values[k]
+	}
+	return 0
+}
+
+func (v *SparseVector) DotProduct(other *Vector) float64 {
+	if v.size != other.Size() {
+		panic(dimensionError("LinAlg.SparseVector.DotProduct", "Vector sizes %d and %d must be the same", v.size, other.Size()))
+	}
+	var d float64
+	for k, idx := range v.indices {
+		d += v.values[k] * other.data[idx]
+	}
+	return d
+}
+
+// Index of the first element of the sorted slice 'a' that is >= x
+func searchInts(a []int, x int) int {
+	lo, hi := 0, len(a)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if a[mid] < x {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// dst = m v for a sparse vector v, touching only the columns of m where v is
+// non-zero
+func MulSparseVecTo(dst *Vector, m *Matrix, v *SparseVector) *Vector {
+	if m.Cols != v.Size() {
+		panic(dimensionError("LinAlg.MulSparseVecTo", "Matrix number of columns %d must equal vector size %d", m.Cols, v.Size()))
+	}
+	if m.Rows != dst.Size() {
+		panic(dimensionError("LinAlg.MulSparseVecTo", "Matrix number of rows %d must equal vector size %d", m.Rows, dst.Size()))
+	}
+	for row := 0; row < m.Rows; row++ {
+		var value float64
+		mRow := m.data[row*m.Cols : (row+1)*m.Cols]
+		for k, col := range v.indices {
+			value += mRow[col] * v.values[k]
+		}
+		dst.data[row] = value
+	}
+	return dst
+}
+
+// Rank-1 update dst += alpha v1 v2^T for a sparse vector v2, touching only
+// the columns of dst where v2 is non-zero
+func AddSparseOuterProductTo(dst *Matrix, alpha float64, v1 *Vector, v2 *SparseVector) *Matrix {
+	if dst.Rows != v1.Size() || dst.Cols != v2.Size() {
+		panic(dimensionError("LinAlg.AddSparseOuterProductTo", "Matrix of size %dx%d does not match vector sizes %d and %d", dst.Rows, dst.Cols, v1.Size(), v2.Size()))
+	}
+	for row := 0; row < dst.Rows; row++ {
+		a := alpha * v1.data[row]
+		if a == 0 {
+			continue
+		}
+		dRow := dst.data[row*dst.Cols : (row+1)*dst.Cols]
+		for k, col := range v2.indices {
+			dRow[col] += a * v2.values[k]
+		}
+	}
+	return dst
+}
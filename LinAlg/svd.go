@@ -0,0 +1,86 @@
+package LinAlg
+
+import (
+	"math"
+	"sort"
+)
+
+// Thin singular value decomposition m = U diag(S) V^T. For an r x c matrix
+// with k = min(r, c), U is r x k, S has size k and V is c x k.
+type SVD struct {
+	U *Matrix
+
+	// singular values in decreasing order
+	S *Vector
+
+	V *Matrix
+}
+
+// Computes the thin SVD with the one-sided Jacobi method. Columns of U
+// belonging to zero singular values are zero.
+func FactorizeSVD(m *Matrix) *SVD {
+	if m.Rows < m.Cols {
+		t := FactorizeSVD(m.Transpose())
+		return &SVD{U: t.V, S: t.S, V: t.U}
+	}
+	rows, cols := m.Rows, m.Cols
+	u := m.Clone()
+	v := MakeEmptyMatrix(cols, cols)
+	for idx := 0; idx < cols; idx++ {
+		v.Set(idx, idx, 1)
+	}
+
+	// rotate pairs of columns of u until they are orthogonal
+	for sweep := 0; sweep < 100; sweep++ {
+		rotated := false
+		for p := 0; p < cols-1; p++ {
+			for q := p + 1; q < cols; q++ {
+				var alpha, beta, gamma float64
+				for row := 0; row < rows; row++ {
+					up, uq := u.Get(row, p), u.Get(row, q)
+					alpha += up * up
+					beta += uq * uq
+					gamma += up * uq
+				}
+				if gamma == 0 || math.Abs(gamma) <= 1e-15*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(zeta*zeta+1))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				rotateCols(u, p, q, c, s)
+				rotateCols(v, p, q, c, s)
+			}
+		}
+		if rotated == false {
+			break
+		}
+	}
+
+	// the column norms of u are the singular values
+	norms := make([]float64, cols)
+	for col := range norms {
+		norms[col] = u.Col(col).EuklideanNorm()
+	}
+	order := make([]int, cols)
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return norms[order[i]] > norms[order[j]]
+	})
+	result := &SVD{U: MakeEmptyMatrix(rows, cols), S: MakeEmptyVector(cols), V: MakeEmptyMatrix(cols, cols)}
+	for k, idx := range order {
+		result.S.Set(k, norms[idx])
+		result.V.SetCol(k, v.Col(idx))
+		if norms[idx] != 0 {
+			result.U.SetCol(k, u.Col(idx).Scalar(1/norms[idx]))
+		}
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package LinAlg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// U diag(S) V^T
+func reconstruct(svd *SVD) *Matrix {
+	us := svd.U.Clone()
+	for col := 0; col < us.Cols; col++ {
+		us.SetCol(col, us.Col(col).Scalar(svd.S.Get(col)))
+	}
+	return us.Am(svd.V.Transpose())
+}
+
+func Test_SVD(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, shape := range [][2]int{{6, 4}, {4, 6}, {5, 5}} {
+		m := randomMatrix(shape[0], shape[1], rng)
+
+		svd := FactorizeSVD(m)
+
+		// Assert
+		k := minInt(shape[0], shape[1])
+		if svd.U.Rows != shape[0] || svd.U.Cols != k || svd.S.Size() != k || svd.V.Rows != shape[1] || svd.V.Cols != k {
+			t.Fatalf("Unexpected thin SVD shapes for %dx%d matrix", shape[0], shape[1])
+		}
+		assertMatricesEqual(t, m, reconstruct(svd))
+		assertMatricesEqual(t, identity(k), svd.U.Transpose().Am(svd.U))
+		assertMatricesEqual(t, identity(k), svd.V.Transpose().Am(svd.V))
+		for idx := 1; idx < k; idx++ {
+			if svd.S.Get(idx) > svd.S.Get(idx-1) {
+				t.Errorf("Singular values must decrease, but %f > %f", svd.S.Get(idx), svd.S.Get(idx-1))
+			}
+		}
+	}
+}
+
+func Test_SVDRankDeficient(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 2, []float64{1, 2, 2, 4, 3, 6})
+
+	// Act
+	svd := FactorizeSVD(m)
+
+	// Assert
+	if floatEquals(svd.S.Get(1), 0, EPSILON) == false {
+		t.Errorf("Second singular value must be 0, but is %f", svd.S.Get(1))
+	}
+	if expected := math.Sqrt(70); floatEquals(svd.S.Get(0), expected, 0.0000001) == false {
+		t.Errorf("First singular value must be %f, but is %f", expected, svd.S.Get(0))
+	}
+	assertMatricesEqual(t, m, reconstruct(svd))
+}
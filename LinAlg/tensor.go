@@ -0,0 +1,411 @@
+package LinAlg
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+)
+
+// N-dimensional array, i.e. a batch of images with channels. Element
+// (i_0, ..., i_{n-1}) is data[offset + sum_k i_k strides[k]]. Reshape,
+// Transpose and BroadcastTo return views sharing the data where possible.
+type Tensor struct {
+	shape   []int
+	strides []int
+	offset  int
+	data    []float64
+}
+
+//
+// Implement interface 'GobEncoder'
+//
+func (t *Tensor) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	err := encoder.Encode(t.shape)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(t.Contiguous().data)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+//
+// Implement interface 'GobDecoder'
+//
+func (t *Tensor) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	var shape []int
+	err := decoder.Decode(&shape)
+	if err != nil {
+		return err
+	}
+	var data []float64
+	err = decoder.Decode(&data)
+	if err != nil {
+		return err
+	}
+	if size, ok := shapeSize(shape); ok == false || size != len(data) {
+		return dimensionError("LinAlg.Tensor.GobDecode", "Tensor data has size %d, but shape is %v", len(data), shape)
+	}
+	*t = Tensor{shape: shape, strides: rowMajorStrides(shape), data: data}
+	return nil
+}
+
+// Creates a tensor of the given shape from data in row-major order
+func MakeTensor(shape []int, data []float64) *Tensor {
+	if size, ok := shapeSize(shape); ok == false || size != len(data) {
+		panic(dimensionError("LinAlg.MakeTensor", "Tensor data has size %d, but shape is %v", len(data), shape))
+	}
+	shape = append([]int(nil), shape...)
+	return &Tensor{shape: shape, strides: rowMajorStrides(shape), data: data}
+}
+
+func MakeEmptyTensor(shape ...int) *Tensor {
+	size, ok := shapeSize(shape)
+	if ok == false {
+		panic(dimensionError("LinAlg.MakeEmptyTensor", "Invalid shape %v", shape))
+	}
+	return MakeTensor(shape, make([]float64, size))
+}
+
+// Number of elements, false for negative dimensions or if the number
+// overflows an int
+func shapeSize(shape []int) (int, bool) {
+	size := 1
+	for _, d := range shape {
+		if d < 0 || (d != 0 && size > math.MaxInt/d) {
+			return 0, false
+		}
+		size *= d
+	}
+	return size, true
+}
+
+func rowMajorStrides(shape []int) []int {
+	strides := make([]int, len(shape))
+	stride := 1
+	for axis := len(shape) - 1; axis >= 0; axis-- {
+		strides[axis] = stride
+		stride *= shape[axis]
+	}
+	return strides
+}
+
+// Shares the data of v
+func (v *Vector) ToTensor() *Tensor {
+	return MakeTensor([]int{v.Size()}, v.data)
+}
+
+// Shares the data of m
+func (m *Matrix) ToTensor() *Tensor {
+	return MakeTensor([]int{m.Rows, m.Cols}, m.data)
+}
+
+// Converts a rank 1 tensor, sharing its data if it is contiguous
+func (t *Tensor) ToVector() *Vector {
+	if t.Rank() != 1 {
+		panic(dimensionError("LinAlg.Tensor.ToVector", "Tensor of shape %v is not rank 1", t.shape))
+	}
+	c := t.Contiguous()
+	return MakeVector(c.data[c.offset : c.offset+c.Size()])
+}
+
+// Converts a rank 2 tensor, sharing its data if it is contiguous
+func (t *Tensor) ToMatrix() *Matrix {
+	if t.Rank() != 2 {
+		panic(dimensionError("LinAlg.Tensor.ToMatrix", "Tensor of shape %v is not rank 2", t.shape))
+	}
+	c := t.Contiguous()
+	return MakeMatrix(t.shape[0], t.shape[1], c.data[c.offset:c.offset+c.Size()])
+}
+
+func (t *Tensor) Shape() []int {
+	return append([]int(nil), t.shape...)
+}
+
+func (t *Tensor) Strides() []int {
+	return append([]int(nil), t.strides...)
+}
+
+func (t *Tensor) Rank() int {
+	return len(t.shape)
+}
+
+// Number of elements
+func (t *Tensor) Size() int {
+	size, _ := shapeSize(t.shape)
+	return size
+}
+
+func (t *Tensor) dataIndex(op string, index []int) int {
+	if len(index) != len(t.shape) {
+		panic(indexError(op, "%d indices for a tensor of rank %d", len(index), len(t.shape)))
+	}
+	idx := t.offset
+	for axis, i := range index {
+		if i < 0 || i >= t.shape[axis] {
+			panic(indexError(op, "Index %v out of range for shape %v", index, t.shape))
+		}
+		idx += i * t.strides[axis]
+	}
+	return idx
+}
+
+func (t *Tensor) Get(index ...int) float64 {
+	return t.data[t.dataIndex("LinAlg.Tensor.Get", index)]
+}
+
+func (t *Tensor) Set(value float64, index ...int) {
+	t.checkWritable("LinAlg.Tensor.Set")
+	t.data[t.dataIndex("LinAlg.Tensor.Set", index)] = value
+}
+
+// Panics for broadcast views, where one element is shared by several indices
+func (t *Tensor) checkWritable(op string) {
+	for axis, d := range t.shape {
+		if d > 1 && t.strides[axis] == 0 {
+			panic(dimensionError(op, "Cannot modify a broadcast view of shape %v", t.shape))
+		}
+	}
+}
+
+// Elements are stored in row-major order without gaps
+func (t *Tensor) IsContiguous() bool {
+	expected := rowMajorStrides(t.shape)
+	for axis, d := range t.shape {
+		if d > 1 && t.strides[axis] != expected[axis] {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns t if it is contiguous, a contiguous copy otherwise
+func (t *Tensor) Contiguous() *Tensor {
+	if t.IsContiguous() {
+		return t
+	}
+	return t.Clone()
+}
+
+// Contiguous copy of t
+func (t *Tensor) Clone() *Tensor {
+	result := MakeEmptyTensor(t.shape...)
+	walk(t.shape, t.strides, t.offset, nil, 0, func(idx int, a int, _ int) {
+		result.data[idx] = t.data[a]
+	})
+	return result
+}
+
+// Returns a tensor with the same elements in row-major order and the given
+// shape. One dimension may be -1, it is inferred from the others. Shares
+// the data of t if t is contiguous.
+func (t *Tensor) Reshape(shape ...int) *Tensor {
+	shape = append([]int(nil), shape...)
+	inferred := -1
+	known := 1
+	for axis, d := range shape {
+		if d == -1 && inferred < 0 {
+			inferred = axis
+			continue
+		}
+		if d < 0 {
+			panic(dimensionError("LinAlg.Tensor.Reshape", "Invalid shape %v", shape))
+		}
+		known *= d
+	}
+	if inferred >= 0 {
+		// with a zero-sized dimension any size would fit
+		if known == 0 {
+			panic(dimensionError("LinAlg.Tensor.Reshape", "Cannot infer dimension -1 of shape %v with a zero-sized dimension", shape))
+		}
+		shape[inferred] = t.Size() / known
+	}
+	if size, ok := shapeSize(shape); ok == false || size != t.Size() {
+		panic(dimensionError("LinAlg.Tensor.Reshape", "Cannot reshape tensor of shape %v to %v", t.shape, shape))
+	}
+	c := t.Contiguous()
+	return &Tensor{shape: shape, strides: rowMajorStrides(shape), offset: c.offset, data: c.data}
+}
+
+// Returns a view with the axes permuted, axis k of the result is axis
+// axes[k] of t. Without arguments the axes are reversed.
+func (t *Tensor) Transpose(axes ...int) *Tensor {
+	rank := t.Rank()
+	if len(axes) == 0 {
+		axes = make([]int, rank)
+		for k := range axes {
+			axes[k] = rank - 1 - k
+		}
+	}
+	if len(axes) != rank {
+		panic(dimensionError("LinAlg.Tensor.Transpose", "%d axes for a tensor of rank %d", len(axes), rank))
+	}
+	seen := make([]bool, rank)
+	result := &Tensor{shape: make([]int, rank), strides: make([]int, rank), offset: t.offset, data: t.data}
+	for k, axis := range axes {
+		if axis < 0 || axis >= rank || seen[axis] {
+			panic(dimensionError("LinAlg.Tensor.Transpose", "Axes %v are not a permutation of 0..%d", axes, rank-1))
+		}
+		seen[axis] = true
+		result.shape[k] = t.shape[axis]
+		result.strides[k] = t.strides[axis]
+	}
+	return result
+}
+
+// Returns a read-only view of t broadcast to 'shape' following the NumPy
+// rules: trailing axes are aligned, and axes of size 1 are repeated
+func (t *Tensor) BroadcastTo(shape ...int) *Tensor {
+	strides, ok := broadcastStrides(t.shape, t.strides, shape)
+	if ok == false {
+		panic(dimensionError("LinAlg.Tensor.BroadcastTo", "Cannot broadcast shape %v to %v", t.shape, shape))
+	}
+	return &Tensor{shape: append([]int(nil), shape...), strides: strides, offset: t.offset, data: t.data}
+}
+
+// Strides of a tensor of shape 'from' broadcast to shape 'to', 0 along
+// repeated axes
+func broadcastStrides(from []int, strides []int, to []int) ([]int, bool) {
+	if len(from) > len(to) {
+		return nil, false
+	}
+	result := make([]int, len(to))
+	shift := len(to) - len(from)
+	for axis := range from {
+		switch {
+		case from[axis] == to[axis+shift]:
+			result[axis+shift] = strides[axis]
+		case from[axis] == 1:
+			result[axis+shift] = 0
+		default:
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+// Shape of the result of an element-wise operation on tensors of shapes a and b
+func broadcastShapes(a []int, b []int) ([]int, bool) {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	result := append([]int(nil), a...)
+	shift := len(a) - len(b)
+	for axis, d := range b {
+		switch {
+		case result[axis+shift] == d || d == 1:
+		case result[axis+shift] == 1:
+			result[axis+shift] = d
+		default:
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+// Calls f for each element of 'shape' in row-major order with the element
+// number and the data indices of two operands with the given strides
+func walk(shape []int, stridesA []int, offsetA int, stridesB []int, offsetB int, f func(idx int, a int, b int)) {
+	size, _ := shapeSize(shape)
+	if size == 0 {
+		return
+	}
+	rank := len(shape)
+	index := make([]int, rank)
+	a, b := offsetA, offsetB
+	for idx := 0; idx < size; idx++ {
+		f(idx, a, b)
+		// increment the multi-index, last axis fastest
+		for axis := rank - 1; axis >= 0; axis-- {
+			index[axis]++
+			a += stridesA[axis]
+			if stridesB != nil {
+				b += stridesB[axis]
+			}
+			if index[axis] < shape[axis] {
+				break
+			}
+			a -= index[axis] * stridesA[axis]
+			if stridesB != nil {
+				b -= index[axis] * stridesB[axis]
+			}
+			index[axis] = 0
+		}
+	}
+}
+
+// Applies 'op' element-wise to t and other, broadcasting them to a common shape
+func (t *Tensor) elementwise(name string, other *Tensor, op func(float64, float64) float64) *Tensor {
+	shape, ok := broadcastShapes(t.shape, other.shape)
+	if ok == false {
+		panic(dimensionError("LinAlg.Tensor."+name, "Shapes %v and %v cannot be broadcast", t.shape, other.shape))
+	}
+	stridesA, _ := broadcastStrides(t.shape, t.strides, shape)
+	stridesB, _ := broadcastStrides(other.shape, other.strides, shape)
+	result := MakeEmptyTensor(shape...)
+	walk(shape, stridesA, t.offset, stridesB, other.offset, func(idx int, a int, b int) {
+		result.data[idx] = op(t.data[a], other.data[b])
+	})
+	return result
+}
+
+func (t *Tensor) Add(other *Tensor) *Tensor {
+	return t.elementwise("Add", other, func(a float64, b float64) float64 { return a + b })
+}
+
+func (t *Tensor) Sub(other *Tensor) *Tensor {
+	return t.elementwise("Sub", other, func(a float64, b float64) float64 { return a - b })
+}
+
+// Element-wise product
+func (t *Tensor) Mul(other *Tensor) *Tensor {
+	return t.elementwise("Mul", other, func(a float64, b float64) float64 { return a * b })
+}
+
+// Element-wise quotient
+func (t *Tensor) Div(other *Tensor) *Tensor {
+	return t.elementwise("Div", other, func(a float64, b float64) float64 { return a / b })
+}
+
+// Returns a new contiguous tensor with 'f' applied to each element
+func (t *Tensor) F(f func(float64) float64) *Tensor {
+	result := MakeEmptyTensor(t.shape...)
+	walk(t.shape, t.strides, t.offset, nil, 0, func(idx int, a int, _ int) {
+		result.data[idx] = f(t.data[a])
+	})
+	return result
+}
+
+// Applies 'f' to each element in place, writing through to the data t
+// shares with other views
+func (t *Tensor) Apply(f func(float64) float64) *Tensor {
+	t.checkWritable("LinAlg.Tensor.Apply")
+	walk(t.shape, t.strides, t.offset, nil, 0, func(_ int, a int, _ int) {
+		t.data[a] = f(t.data[a])
+	})
+	return t
+}
+
+// Multiplies each element by 'scalar', in place
+func (t *Tensor) Scalar(scalar float64) *Tensor {
+	return t.Apply(func(e float64) float64 { return e * scalar })
+}
+
+// Adds 'scalar' to each element, in place
+func (t *Tensor) AddScalar(scalar float64) *Tensor {
+	return t.Apply(func(e float64) float64 { return e + scalar })
+}
+
+func (t *Tensor) Sum() float64 {
+	var s float64
+	walk(t.shape, t.strides, t.offset, nil, 0, func(_ int, a int, _ int) {
+		s += t.data[a]
+	})
+	return s
+}
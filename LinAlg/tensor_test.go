@@ -0,0 +1,214 @@
+package LinAlg
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func assertTensorData(t *testing.T, expectedShape []int, expected []float64, actual *Tensor) {
+	t.Helper()
+	shape := actual.Shape()
+	if len(shape) != len(expectedShape) {
+		t.Fatalf("Shape error, %v != %v", expectedShape, shape)
+	}
+	for axis := range shape {
+		if shape[axis] != expectedShape[axis] {
+			t.Fatalf("Shape error, %v != %v", expectedShape, shape)
+		}
+	}
+	data := actual.Contiguous()
+	for i, e := range expected {
+		if a := data.data[data.offset+i]; !floatEquals(e, a, EPSILON) {
+			t.Errorf("Tensor element %d error, %f != %f", i, e, a)
+		}
+	}
+}
+
+func Test_TensorGetSet(t *testing.T) {
+	// Arrange
+	tensor := MakeTensor([]int{2, 3, 4}, make([]float64, 24))
+
+	// Act
+	tensor.Set(5, 1, 2, 3)
+
+	// Assert
+	if tensor.Get(1, 2, 3) != 5 || tensor.data[23] != 5 {
+		t.Error("Set/Get must use row-major order")
+	}
+	if tensor.Size() != 24 || tensor.Rank() != 3 {
+		t.Errorf("Size/Rank error, %d, %d", tensor.Size(), tensor.Rank())
+	}
+	err := Try(func() { tensor.Get(2, 0, 0) })
+	if _, ok := err.(*IndexError); ok == false {
+		t.Errorf("Out of range index must raise an IndexError, got %v", err)
+	}
+}
+
+func Test_TensorReshape(t *testing.T) {
+	// Arrange
+	tensor := MakeTensor([]int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	// Act
+	reshaped := tensor.Reshape(3, -1)
+	reshaped.Set(-1, 0, 0)
+
+	// Assert
+	assertTensorData(t, []int{3, 2}, []float64{-1, 2, 3, 4, 5, 6}, reshaped)
+	if tensor.Get(0, 0) != -1 {
+		t.Error("Reshape of a contiguous tensor must share its data")
+	}
+	err := Try(func() { tensor.Reshape(4, 2) })
+	if _, ok := err.(*DimensionError); ok == false {
+		t.Errorf("Invalid reshape must raise a DimensionError, got %v", err)
+	}
+}
+
+func Test_TensorReshapeInferZeroSized(t *testing.T) {
+	// Arrange
+	tensor := MakeEmptyTensor(0, 3)
+
+	// Act
+	err := Try(func() { tensor.Reshape(0, -1) })
+	reshaped := tensor.Reshape(3, 0)
+
+	// Assert
+	if _, ok := err.(*DimensionError); ok == false {
+		t.Errorf("Inferring -1 next to a zero-sized dimension must raise a DimensionError, got %v", err)
+	}
+	assertTensorData(t, []int{3, 0}, nil, reshaped)
+}
+
+func Test_TensorTranspose(t *testing.T) {
+	// Arrange
+	tensor := MakeTensor([]int{2, 3, 2}, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+
+	// Act
+	transposed := tensor.Transpose(2, 0, 1)
+
+	// Assert
+	if transposed.IsContiguous() {
+		t.Error("Transposed tensor must be a strided view")
+	}
+	assertTensorData(t, []int{2, 2, 3}, []float64{1, 3, 5, 7, 9, 11, 2, 4, 6, 8, 10, 12}, transposed)
+	if transposed.Get(1, 1, 2) != tensor.Get(1, 2, 1) {
+		t.Error("Transpose element mismatch")
+	}
+	assertTensorData(t, []int{12}, []float64{1, 3, 5, 7, 9, 11, 2, 4, 6, 8, 10, 12}, transposed.Reshape(-1))
+}
+
+func Test_TensorBroadcasting(t *testing.T) {
+	// Arrange
+	a := MakeTensor([]int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	row := MakeTensor([]int{3}, []float64{10, 20, 30})
+	col := MakeTensor([]int{2, 1}, []float64{2, 4})
+
+	// Act
+	sum := a.Add(row)
+	product := a.Mul(col)
+	outer := col.Sub(row)
+	quotient := a.Div(col)
+
+	// Assert
+	assertTensorData(t, []int{2, 3}, []float64{11, 22, 33, 14, 25, 36}, sum)
+	assertTensorData(t, []int{2, 3}, []float64{2, 4, 6, 16, 20, 24}, product)
+	assertTensorData(t, []int{2, 3}, []float64{-8, -18, -28, -6, -16, -26}, outer)
+	assertTensorData(t, []int{2, 3}, []float64{0.5, 1, 1.5, 1, 1.25, 1.5}, quotient)
+	err := Try(func() { a.Add(MakeEmptyTensor(2)) })
+	if _, ok := err.(*DimensionError); ok == false {
+		t.Errorf("Incompatible shapes must raise a DimensionError, got %v", err)
+	}
+}
+
+func Test_TensorElementwise(t *testing.T) {
+	// Arrange
+	a := MakeTensor([]int{2, 2}, []float64{1, 2, 3, 4})
+
+	// Act
+	squared := a.F(func(e float64) float64 { return e * e })
+	scaled := a.Transpose().Scalar(2)
+	shifted := a.Clone().AddScalar(1)
+
+	// Assert
+	assertTensorData(t, []int{2, 2}, []float64{1, 4, 9, 16}, squared)
+	assertTensorData(t, []int{2, 2}, []float64{2, 6, 4, 8}, scaled)
+	assertTensorData(t, []int{2, 2}, []float64{2, 4, 6, 8}, a)
+	assertTensorData(t, []int{2, 2}, []float64{3, 5, 7, 9}, shifted)
+	if expected := float64(20); !floatEquals(expected, scaled.Sum(), EPSILON) {
+		t.Errorf("Sum error, %f != %f", expected, scaled.Sum())
+	}
+	err := Try(func() { MakeEmptyTensor(2).BroadcastTo(3, 2).AddScalar(1) })
+	if _, ok := err.(*DimensionError); ok == false {
+		t.Errorf("Modifying a broadcast view must raise a DimensionError, got %v", err)
+	}
+	row := MakeEmptyTensor(2)
+	err = Try(func() { row.BroadcastTo(3, 2).Set(1, 0, 0) })
+	if _, ok := err.(*DimensionError); ok == false || row.Get(0) != 0 {
+		t.Errorf("Setting an element of a broadcast view must raise a DimensionError, got %v", err)
+	}
+}
+
+func Test_TensorConversions(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	v := MakeVector([]float64{1, 2, 3})
+
+	// Act
+	tm := m.ToTensor()
+	transposed := tm.Transpose().ToMatrix()
+	vector := v.ToTensor().Add(tm).Reshape(-1).ToVector()
+
+	// Assert
+	assertMatricesEqual(t, m.Transpose(), transposed)
+	expected := MakeVector([]float64{2, 4, 6, 5, 7, 9})
+	if ok, diff := expected.EqualApprox(vector, 1e-12, 0); ok == false {
+		t.Error(diff)
+	}
+	err := Try(func() { tm.ToVector() })
+	if _, ok := err.(*DimensionError); ok == false {
+		t.Errorf("Converting rank 2 to Vector must raise a DimensionError, got %v", err)
+	}
+}
+
+func Test_TensorGob(t *testing.T) {
+	// Arrange
+	tensor := MakeTensor([]int{2, 1, 3}, []float64{1, 2, 3, 4, 5, 6}).Transpose(2, 1, 0)
+	var buf bytes.Buffer
+
+	// Act
+	err := gob.NewEncoder(&buf).Encode(tensor)
+	decoded := new(Tensor)
+	if err == nil {
+		err = gob.NewDecoder(&buf).Decode(decoded)
+	}
+
+	// Assert
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTensorData(t, []int{3, 1, 2}, []float64{1, 4, 2, 5, 3, 6}, decoded)
+}
+
+func Test_TensorGobRejectsInconsistentShape(t *testing.T) {
+	shapes := [][]int{
+		{2, 3},
+		// the product overflows to 4
+		{1<<62 + 1, 4},
+		{-2, -2},
+	}
+	for _, shape := range shapes {
+		// Arrange, encoded like Tensor.GobEncode
+		var payload bytes.Buffer
+		encoder := gob.NewEncoder(&payload)
+		encoder.Encode(shape)
+		encoder.Encode([]float64{1, 2, 3, 4})
+
+		// Act
+		err := new(Tensor).GobDecode(payload.Bytes())
+
+		// Assert
+		if _, ok := err.(*DimensionError); ok == false {
+			t.Errorf("Shape %v with 4 elements must raise a DimensionError, got %v", shape, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package LinAlg
+
+// Transposed view of a matrix. Shares the data of the matrix, so changes to
+// the matrix are visible through the view.
+type TransposedMatrix struct {
+	m *Matrix
+}
+
+func (m *Matrix) T() TransposedMatrix {
+	return TransposedMatrix{m}
+}
+
+func (t TransposedMatrix) Rows() int {
+	return t.m.Cols
+}
+
+func (t TransposedMatrix) Cols() int {
+	return t.m.Rows
+}
+
+func (t TransposedMatrix) Get(row int, col int) float64 {
+	return t.m.Get(col, row)
+}
+
+func (t TransposedMatrix) Set(row int, col int, value float64) {
+	t.m.Set(col, row, value)
+}
+
+func (t TransposedMatrix) Ax(v *Vector) *Vector {
+	return t.m.TransposeAx(v)
+}
+
+// The matrix the view was created from
+func (t TransposedMatrix) T() *Matrix {
+	return t.m
+}
+
+// Copies the view into a new matrix
+func (t TransposedMatrix) Materialize() *Matrix {
+	return t.m.Transpose()
+}
@@ -0,0 +1,54 @@
+package LinAlg
+
+import "testing"
+
+func Test_TransposeAx(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 2, []float64{3, 4, -2, -9, 4, 7})
+	v := MakeVector([]float64{1, 2, -1})
+
+	// Act
+	r := m.TransposeAx(v)
+
+	// Assert
+	expected := m.Transpose().Ax(v)
+	if r.Size() != 2 {
+		t.Fatalf("Resulting vector must have size 2, but is %d", r.Size())
+	}
+	for idx := 0; idx < 2; idx++ {
+		if floatEquals(r.Get(idx), expected.Get(idx), EPSILON) == false {
+			t.Errorf("TransposeAx error, %f != %f", expected.Get(idx), r.Get(idx))
+		}
+	}
+}
+
+func Test_TransposedView(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 2, []float64{3, 4, -2, -9, 4, 7})
+
+	// Act
+	mt := m.T()
+	mt.Set(1, 2, 8)
+
+	// Assert
+	if mt.Rows() != 2 || mt.Cols() != 3 {
+		t.Errorf("Transposed view must be 2x3, but is %dx%d", mt.Rows(), mt.Cols())
+	}
+	if expected := float64(-2); mt.Get(0, 1) != expected {
+		t.Errorf("Transposed view error, %f != %f", expected, mt.Get(0, 1))
+	}
+	if m.Get(2, 1) != 8 {
+		t.Error("Transposed view must share the data of the matrix")
+	}
+	if mt.T() != m {
+		t.Error("Transposing the view must give the matrix")
+	}
+	materialized := mt.Materialize()
+	for row := 0; row < 2; row++ {
+		for col := 0; col < 3; col++ {
+			if materialized.Get(row, col) != mt.Get(row, col) {
+				t.Errorf("Materialized view differs at (%d, %d)", row, col)
+			}
+		}
+	}
+}
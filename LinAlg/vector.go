@@ -3,7 +3,6 @@ package LinAlg
 import (
 	"bytes"
 	"encoding/gob"
-	"fmt"
 	"math"
 )
 
@@ -55,7 +54,7 @@ func (v *Vector) Get(index int) float64 {
 
 func (v *Vector) DotProduct(v2 *Vector) float64 {
 	if v.Size() != v2.Size() {
-		panic(fmt.Sprintf("LinAlg.Vector.DotProduct: Vector sizes %d and %d must be the same", v.Size(), v2.Size()))
+		panic(dimensionError("LinAlg.Vector.DotProduct", "Vector sizes %d and %d must be the same", v.Size(), v2.Size()))
 	}
 	var d float64 = 0
 	for i := 0; i < v.Size(); i++ {
@@ -68,7 +67,7 @@ func (v *Vector) DotProduct(v2 *Vector) float64 {
 
 func (v *Vector) Add(v2 *Vector) *Vector {
 	if v.Size() != v2.Size() {
-		panic(fmt.Sprintf("LinAlg.Vector.Add: Vector sizes %d and %d must be the same", v.Size(), v2.Size()))
+		panic(dimensionError("LinAlg.Vector.Add", "Vector sizes %d and %d must be the same", v.Size(), v2.Size()))
 	}
 	for idx := range v.data {
 		e1 := v.data[idx]
@@ -80,7 +79,7 @@ func (v *Vector) Add(v2 *Vector) *Vector {
 
 func (v *Vector) Sub(v2 *Vector) *Vector {
 	if v.Size() != v2.Size() {
-		panic(fmt.Sprintf("LinAlg.Vector.Sub: Vector sizes %d and %d must be the same", v.Size(), v2.Size()))
+		panic(dimensionError("LinAlg.Vector.Sub", "Vector sizes %d and %d must be the same", v.Size(), v2.Size()))
 	}
 	for idx := range v.data {
 		e1 := v.data[idx]
@@ -98,26 +97,14 @@ func (v *Vector) Scalar(scalar float64) *Vector {
 }
 
 func (v *Vector) F(f func(float64) float64) *Vector {
-	result := MakeEmptyVector(v.Size())
-	for idx := range v.data {
-		value := v.data[idx]
-		value = f(value)
-		result.data[idx] = value
-	}
-	return result
+	return FTo(MakeEmptyVector(v.Size()), v, f)
 }
 
 func (v *Vector) Hadamard(other *Vector) *Vector {
 	if v.Size() != other.Size() {
-		panic(fmt.Sprintf("LinAlg.Vector.Hadamard: Vectors must have same size, but is %d and %d", v.Size(), other.Size()))
-	}
-	result := MakeEmptyVector(v.Size())
-	for idx := range v.data {
-		e1 := v.data[idx]
-		e2 := other.data[idx]
-		result.Set(idx, e1*e2)
+		panic(dimensionError("LinAlg.Vector.Hadamard", "Vectors must have same size, but is %d and %d", v.Size(), other.Size()))
 	}
-	return result
+	return HadamardTo(MakeEmptyVector(v.Size()), v, other)
 }
 
 func (v *Vector) EuklideanNorm() float64 {
@@ -0,0 +1,129 @@
+package LinAlg
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+)
+
+// Single precision counterpart of Vector, i.e. for inference where float64
+// precision is not needed
+type Vector32 struct {
+	data []float32
+}
+
+//
+// Implement interface 'GobEncoder'
+//
+func (v *Vector32) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	err := encoder.Encode(v.data)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+//
+// Implement interface 'GobDecoder'
+//
+func (v *Vector32) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	return decoder.Decode(&v.data)
+}
+
+func MakeVector32(data []float32) *Vector32 {
+	return &Vector32{data: data}
+}
+
+func MakeEmptyVector32(size int) *Vector32 {
+	return &Vector32{data: make([]float32, size)}
+}
+
+func (v *Vector) ToFloat32() *Vector32 {
+	result := MakeEmptyVector32(v.Size())
+	for idx, e := range v.data {
+		result.data[idx] = float32(e)
+	}
+	return result
+}
+
+func (v *Vector32) ToFloat64() *Vector {
+	result := MakeEmptyVector(v.Size())
+	for idx, e := range v.data {
+		result.data[idx] = float64(e)
+	}
+	return result
+}
+
+func (v *Vector32) Size() int {
+	return len(v.data)
+}
+
+func (v Vector32) Set(index int, value float32) {
+	v.data[index] = value
+}
+
+func (v *Vector32) Get(index int) float32 {
+	return v.data[index]
+}
+
+func (v *Vector32) DotProduct(v2 *Vector32) float32 {
+	if v.Size() != v2.Size() {
+		panic(dimensionError("LinAlg.Vector32.DotProduct", "Vector sizes %d and %d must be the same", v.Size(), v2.Size()))
+	}
+	var d float32 = 0
+	for idx := range v.data {
+		d += v.data[idx] * v2.data[idx]
+	}
+	return d
+}
+
+func (v *Vector32) Add(v2 *Vector32) *Vector32 {
+	if v.Size() != v2.Size() {
+		panic(dimensionError("LinAlg.Vector32.Add", "Vector sizes %d and %d must be the same", v.Size(), v2.Size()))
+	}
+	for idx := range v.data {
+		v.data[idx] += v2.data[idx]
+	}
+	return v
+}
+
+func (v *Vector32) Sub(v2 *Vector32) *Vector32 {
+	if v.Size() != v2.Size() {
+		panic(dimensionError("LinAlg.Vector32.Sub", "Vector sizes %d and %d must be the same", v.Size(), v2.Size()))
+	}
+	for idx := range v.data {
+		v.data[idx] -= v2.data[idx]
+	}
+	return v
+}
+
+func (v *Vector32) Scalar(scalar float32) *Vector32 {
+	for idx := range v.data {
+		v.data[idx] *= scalar
+	}
+	return v
+}
+
+func (v *Vector32) F(f func(float32) float32) *Vector32 {
+	return F32To(MakeEmptyVector32(v.Size()), v, f)
+}
+
+func (v *Vector32) Hadamard(other *Vector32) *Vector32 {
+	if v.Size() != other.Size() {
+		panic(dimensionError("LinAlg.Vector32.Hadamard", "Vectors must have same size, but is %d and %d", v.Size(), other.Size()))
+	}
+	return Hadamard32To(MakeEmptyVector32(v.Size()), v, other)
+}
+
+func (v *Vector32) EuklideanNorm() float32 {
+	// accumulate in float64 to not lose precision for long vectors
+	var err float64
+	for _, e := range v.data {
+		err += float64(e) * float64(e)
+	}
+	return float32(math.Sqrt(err))
+}
@@ -0,0 +1,77 @@
+package LinAlg
+
+import (
+	"SimpleNeuralNet/Utility"
+	"bytes"
+	"testing"
+)
+
+func Test_Vector32Conversion(t *testing.T) {
+	// Arrange
+	v := MakeVector([]float64{1.5, -2.25})
+
+	// Act
+	v32 := v.ToFloat32()
+	v64 := v32.ToFloat64()
+
+	// Assert
+	if v32.Size() != 2 {
+		t.Fatalf("Converted vector must have size 2, but is %d", v32.Size())
+	}
+	if expected := float32(-2.25); v32.Get(1) != expected {
+		t.Errorf("Conversion error, %f != %f", expected, v32.Get(1))
+	}
+	for idx := 0; idx < 2; idx++ {
+		if floatEquals(v64.Get(idx), v.Get(idx), EPSILON) == false {
+			t.Errorf("Round trip error, %f != %f", v.Get(idx), v64.Get(idx))
+		}
+	}
+}
+
+func Test_Vector32Operations(t *testing.T) {
+	// Arrange
+	v1 := MakeVector32([]float32{1, 2})
+	v2 := MakeVector32([]float32{3, 4})
+
+	// Act
+	dp := v1.DotProduct(v2)
+	h := v1.Hadamard(v2)
+	v1.Add(v2).Scalar(2)
+
+	// Assert
+	if expected := float32(11); dp != expected {
+		t.Errorf("Dot product error, %f != %f", expected, dp)
+	}
+	if expected := float32(8); h.Get(1) != expected {
+		t.Errorf("Hadamard error, %f != %f", expected, h.Get(1))
+	}
+	if expected := float32(12); v1.Get(1) != expected {
+		t.Errorf("Add error, %f != %f", expected, v1.Get(1))
+	}
+}
+
+func TestVector32Serialization(t *testing.T) {
+	// Arrange
+	v1 := MakeVector32([]float32{1, 2})
+
+	// Act
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, v1)
+	if err != nil {
+		t.Errorf("Error serializing vector")
+	}
+
+	v2 := new(Vector32)
+	err = Utility.ReadGob(&buf, v2)
+	if err != nil {
+		t.Error("Error deserializing vector")
+	}
+
+	// Assert
+	if expected := v1.Size(); v2.Size() != expected {
+		t.Fatalf("Vector size must be %d, but is %d", expected, v2.Size())
+	}
+	if expected := v1.Get(1); v2.Get(1) != expected {
+		t.Errorf("Serialization error, %f != %f", expected, v2.Get(1))
+	}
+}
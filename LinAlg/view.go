@@ -0,0 +1,160 @@
+package LinAlg
+
+// Returns row 'row' of m as a vector sharing the data of m, i.e. the
+// incoming weights of a neuron
+func (m *Matrix) RowView(row int) *Vector {
+	if row < 0 || row >= m.Rows {
+		panic(indexError("LinAlg.Matrix.RowView", "Row %d out of range [0, %d)", row, m.Rows))
+	}
+	start := row * m.Cols
+	return &Vector{data: m.data[start : start+m.Cols : start+m.Cols]}
+}
+
+// Returns a copy of row 'row'
+func (m *Matrix) Row(row int) *Vector {
+	return m.RowView(row).Clone()
+}
+
+// Returns a copy of column 'col'
+func (m *Matrix) Col(col int) *Vector {
+	if col < 0 || col >= m.Cols {
+		panic(indexError("LinAlg.Matrix.Col", "Column %d out of range [0, %d)", col, m.Cols))
+	}
+	result := MakeEmptyVector(m.Rows)
+	for row := range result.data {
+		result.data[row] = m.data[row*m.Cols+col]
+	}
+	return result
+}
+
+func (m *Matrix) SetRow(row int, v *Vector) {
+	if v.Size() != m.Cols {
+		panic(dimensionError("LinAlg.Matrix.SetRow", "Vector size %d must equal matrix number of columns %d", v.Size(), m.Cols))
+	}
+	copy(m.RowView(row).data, v.data)
+}
+
+func (m *Matrix) SetCol(col int, v *Vector) {
+	if col < 0 || col >= m.Cols {
+		panic(indexError("LinAlg.Matrix.SetCol", "Column %d out of range [0, %d)", col, m.Cols))
+	}
+	if v.Size() != m.Rows {
+		panic(dimensionError("LinAlg.Matrix.SetCol", "Vector size %d must equal matrix number of rows %d", v.Size(), m.Rows))
+	}
+	for row, e := range v.data {
+		m.data[row*m.Cols+col] = e
+	}
+}
+
+// Copies the elements of 'other' into m
+func (m *Matrix) Copy(other *Matrix) *Matrix {
+	if m.Rows != other.Rows || m.Cols != other.Cols {
+		panic(dimensionError("LinAlg.Matrix.Copy", "Matrix of size %dx%d cannot hold a %dx%d matrix", m.Rows, m.Cols, other.Rows, other.Cols))
+	}
+	copy(m.data, other.data)
+	return m
+}
+
+func (m *Matrix) Clone() *Matrix {
+	return MakeEmptyMatrix(m.Rows, m.Cols).Copy(m)
+}
+
+// Returns a matrix with the same elements in row-major order and the given
+// shape, sharing the data of m
+func (m *Matrix) Reshape(rows int, cols int) *Matrix {
+	if size, ok := shapeSize([]int{rows, cols}); ok == false || size != len(m.data) {
+		panic(dimensionError("LinAlg.Matrix.Reshape", "Cannot reshape %dx%d matrix to %dx%d", m.Rows, m.Cols, rows, cols))
+	}
+	return &Matrix{Rows: rows, Cols: cols, data: m.data}
+}
+
+// Copies the elements of 'other' into v
+func (v *Vector) Copy(other *Vector) *Vector {
+	if v.Size() != other.Size() {
+		panic(dimensionError("LinAlg.Vector.Copy", "Vector sizes %d and %d must be the same", v.Size(), other.Size()))
+	}
+	copy(v.data, other.data)
+	return v
+}
+
+func (v *Vector) Clone() *Vector {
+	return MakeEmptyVector(v.Size()).Copy(v)
+}
+
+// Rectangular block of a matrix, sharing its data. Consecutive rows of the
+// block are 'stride' elements apart in 'data'.
+type MatrixView struct {
+	Rows   int
+	Cols   int
+	stride int
+	data   []float64
+}
+
+// Returns the rows x cols block of m starting at (row, col)
+func (m *Matrix) View(row int, col int, rows int, cols int) *MatrixView {
+	return m.AsView().View(row, col, rows, cols)
+}
+
+// Returns a view of all of m
+func (m *Matrix) AsView() *MatrixView {
+	return &MatrixView{Rows: m.Rows, Cols: m.Cols, stride: m.Cols, data: m.data}
+}
+
+// Returns the rows x cols block of the view starting at (row, col)
+func (v *MatrixView) View(row int, col int, rows int, cols int) *MatrixView {
+	if row < 0 || col < 0 || rows < 0 || cols < 0 || row+rows > v.Rows || col+cols > v.Cols {
+		panic(dimensionError("LinAlg.MatrixView.View", "Block of size %dx%d at (%d, %d) exceeds %dx%d matrix", rows, cols, row, col, v.Rows, v.Cols))
+	}
+	if rows == 0 || cols == 0 {
+		return &MatrixView{Rows: rows, Cols: cols, stride: v.stride}
+	}
+	start := row*v.stride + col
+	end := start + (rows-1)*v.stride + cols
+	return &MatrixView{Rows: rows, Cols: cols, stride: v.stride, data: v.data[start:end:end]}
+}
+
+// Index into data, panics unless (row, col) lies inside the block, since
+// indices beyond it would still address the parent matrix
+func (v *MatrixView) index(op string, row int, col int) int {
+	if row < 0 || row >= v.Rows || col < 0 || col >= v.Cols {
+		panic(indexError(op, "Index (%d, %d) out of range for a %dx%d view", row, col, v.Rows, v.Cols))
+	}
+	return row*v.stride + col
+}
+
+func (v *MatrixView) Get(row int, col int) float64 {
+	return v.data[v.index("LinAlg.MatrixView.Get", row, col)]
+}
+
+func (v *MatrixView) Set(row int, col int, value float64) {
+	v.data[v.index("LinAlg.MatrixView.Set", row, col)] = value
+}
+
+// Returns row 'row' of the view as a vector sharing its data
+func (v *MatrixView) RowView(row int) *Vector {
+	if row < 0 || row >= v.Rows {
+		panic(indexError("LinAlg.MatrixView.RowView", "Row %d out of range [0, %d)", row, v.Rows))
+	}
+	start := row * v.stride
+	return &Vector{data: v.data[start : start+v.Cols : start+v.Cols]}
+}
+
+// Copies the view into a new matrix
+func (v *MatrixView) ToMatrix() *Matrix {
+	result := MakeEmptyMatrix(v.Rows, v.Cols)
+	for row := 0; row < v.Rows; row++ {
+		copy(result.data[row*v.Cols:(row+1)*v.Cols], v.data[row*v.stride:row*v.stride+v.Cols])
+	}
+	return result
+}
+
+func (v *MatrixView) Ax(x *Vector) *Vector {
+	if v.Cols != x.Size() {
+		panic(dimensionError("LinAlg.MatrixView.Ax", "Matrix number of columns %d must equal vector size %d", v.Cols, x.Size()))
+	}
+	result := MakeEmptyVector(v.Rows)
+	for row := range result.data {
+		result.data[row] = v.RowView(row).DotProduct(x)
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package LinAlg
+
+import "testing"
+
+func Test_RowView(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+
+	// Act
+	r := m.RowView(1)
+	r.Set(0, -4)
+
+	// Assert
+	if r.Size() != 3 {
+		t.Fatalf("Row must have size 3, but is %d", r.Size())
+	}
+	if expected := float64(5); r.Get(1) != expected {
+		t.Errorf("Row view error, %f != %f", expected, r.Get(1))
+	}
+	if m.Get(1, 0) != -4 {
+		t.Error("Row view must share the data of the matrix")
+	}
+}
+
+func Test_RowColAccessors(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+
+	// Act
+	row := m.Row(0)
+	col := m.Col(2)
+	row.Set(0, 100)
+	m.SetRow(1, MakeVector([]float64{7, 8, 9}))
+	m.SetCol(0, MakeVector([]float64{-1, -2}))
+
+	// Assert
+	if expected := float64(6); col.Get(1) != expected {
+		t.Errorf("Col error, %f != %f", expected, col.Get(1))
+	}
+	expected := MakeMatrix(2, 3, []float64{-1, 2, 3, -2, 8, 9})
+	assertMatricesEqual(t, expected, m)
+}
+
+func Test_CloneAndReshape(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+
+	// Act
+	c := m.Clone()
+	r := m.Reshape(3, 2)
+	c.Set(0, 0, 10)
+	r.Set(2, 1, 60)
+
+	// Assert
+	if m.Get(0, 0) != 1 {
+		t.Error("Clone must not share the data of the matrix")
+	}
+	if m.Get(1, 2) != 60 {
+		t.Error("Reshaped matrix must share the data of the matrix")
+	}
+	if expected := float64(3); r.Get(1, 0) != expected {
+		t.Errorf("Reshape error, %f != %f", expected, r.Get(1, 0))
+	}
+	err := Try(func() { m.Reshape(-2, -3) })
+	if _, ok := err.(*DimensionError); ok == false {
+		t.Errorf("Negative dimensions must raise a DimensionError, got %v", err)
+	}
+}
+
+func Test_MatrixView(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+
+	// Act
+	v := m.View(1, 1, 2, 2)
+	v.Set(0, 1, -7)
+	inner := v.View(1, 0, 1, 2)
+	ax := v.Ax(MakeVector([]float64{1, 1}))
+
+	// Assert
+	expected := MakeMatrix(2, 2, []float64{6, -7, 10, 11})
+	assertMatricesEqual(t, expected, v.ToMatrix())
+	if m.Get(1, 2) != -7 {
+		t.Error("Matrix view must share the data of the matrix")
+	}
+	assertMatricesEqual(t, MakeMatrix(1, 2, []float64{10, 11}), inner.ToMatrix())
+	if ax.Get(0) != -1 || ax.Get(1) != 21 {
+		t.Errorf("Ax error, (%f, %f) != (-1, 21)", ax.Get(0), ax.Get(1))
+	}
+	if row := v.RowView(1); row.Size() != 2 || row.Get(1) != 11 {
+		t.Error("Row view of a matrix view must only cover the view")
+	}
+}
+
+func Test_MatrixViewBounds(t *testing.T) {
+	// Arrange
+	m := MakeMatrix(3, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	v := m.View(1, 1, 2, 2)
+
+	// Act, column 2 of the block would address m(1, 3)
+	getErr := Try(func() { v.Get(0, 2) })
+	setErr := Try(func() { v.Set(0, 2, -1) })
+
+	// Assert
+	if _, ok := getErr.(*IndexError); ok == false {
+		t.Errorf("Get outside the view must raise an IndexError, got %v", getErr)
+	}
+	if _, ok := setErr.(*IndexError); ok == false {
+		t.Errorf("Set outside the view must raise an IndexError, got %v", setErr)
+	}
+	if m.Get(1, 3) != 8 {
+		t.Error("Set outside the view must not modify the matrix")
+	}
+}
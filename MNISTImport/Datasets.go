@@ -0,0 +1,168 @@
+package MNISTImport
+
+import (
+	"fmt"
+	"path"
+)
+
+// Describes a data set stored in the IDX format of the MNIST data set
+type Dataset struct {
+	Name string
+
+	TrainImageFile string
+	TrainLabelFile string
+	TestImageFile  string
+	TestLabelFile  string
+
+	// Class names, in the order of the output activations
+	ClassNames []string
+
+	// Label of the first class in the label files, i.e. EMNIST letters
+	// labels 'a' as 1
+	FirstLabel byte
+
+	// Images are stored column by column instead of row by row
+	Transposed bool
+}
+
+var MNIST = Dataset{
+	Name:           "MNIST",
+	TrainImageFile: "train-images.idx3-ubyte",
+	TrainLabelFile: "train-labels.idx1-ubyte",
+	TestImageFile:  "t10k-images.idx3-ubyte",
+	TestLabelFile:  "t10k-labels.idx1-ubyte",
+	ClassNames:     digits,
+}
+
+var FashionMNIST = Dataset{
+	Name:           "Fashion-MNIST",
+	TrainImageFile: "train-images-idx3-ubyte",
+	TrainLabelFile: "train-labels-idx1-ubyte",
+	TestImageFile:  "t10k-images-idx3-ubyte",
+	TestLabelFile:  "t10k-labels-idx1-ubyte",
+	ClassNames:     []string{"T-shirt/top", "Trouser", "Pullover", "Dress", "Coat", "Sandal", "Shirt", "Sneaker", "Bag", "Ankle boot"},
+}
+
+var KMNIST = Dataset{
+	Name:           "Kuzushiji-MNIST",
+	TrainImageFile: "train-images-idx3-ubyte",
+	TrainLabelFile: "train-labels-idx1-ubyte",
+	TestImageFile:  "t10k-images-idx3-ubyte",
+	TestLabelFile:  "t10k-labels-idx1-ubyte",
+	ClassNames:     []string{"o", "ki", "su", "tsu", "na", "ha", "ma", "ya", "re", "wo"},
+}
+
+var EMNISTLetters = emnist("letters", letters(), 1)
+var EMNISTBalanced = emnist("balanced", concat(digits, upperCase(), []string{"a", "b", "d", "e", "f", "g", "h", "n", "q", "r", "t"}), 0)
+var EMNISTByMerge = emnist("bymerge", EMNISTBalanced.ClassNames, 0)
+var EMNISTByClass = emnist("byclass", concat(digits, upperCase(), letters()), 0)
+var EMNISTDigits = emnist("digits", digits, 0)
+var EMNISTMNIST = emnist("mnist", digits, 0)
+
+var digits = []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+
+func emnist(split string, classNames []string, firstLabel byte) Dataset {
+	prefix := "emnist-" + split
+	return Dataset{
+		Name:           "EMNIST " + split,
+		TrainImageFile: prefix + "-train-images-idx3-ubyte",
+		TrainLabelFile: prefix + "-train-labels-idx1-ubyte",
+		TestImageFile:  prefix + "-test-images-idx3-ubyte",
+		TestLabelFile:  prefix + "-test-labels-idx1-ubyte",
+		ClassNames:     classNames,
+		FirstLabel:     firstLabel,
+		Transposed:     true,
+	}
+}
+
+func letters() []string {
+	result := make([]string, 26)
+	for idx := range result {
+		result[idx] = string(rune('a' + idx))
+	}
+	return result
+}
+
+func upperCase() []string {
+	result := make([]string, 26)
+	for idx := range result {
+		result[idx] = string(rune('A' + idx))
+	}
+	return result
+}
+
+func concat(names ...[]string) []string {
+	var result []string
+	for _, n := range names {
+		result = append(result, n...)
+	}
+	return result
+}
+
+func (d Dataset) Classes() int {
+	return len(d.ClassNames)
+}
+
+func (d Dataset) ImportTrainingData(dir string) MNISTData {
+	return d.Import(dir, d.TrainImageFile, d.TrainLabelFile)
+}
+
+func (d Dataset) ImportTestData(dir string) MNISTData {
+	return d.Import(dir, d.TestImageFile, d.TestLabelFile)
+}
+
+func (d Dataset) Import(dir string, imageFile string, labelFile string) MNISTData {
+	var output MNISTData
+	output.inputActivations, output.nRows, output.nCols = importImageFile(path.Join(dir, imageFile))
+	output.expectedResult = ImportLabelFile(path.Join(dir, labelFile))
+	output.nClasses = d.Classes()
+	if d.Transposed {
+		for idx, img := range output.inputActivations {
+			output.inputActivations[idx] = transposeImage(img, output.nRows, output.nCols)
+		}
+		output.nRows, output.nCols = output.nCols, output.nRows
+	}
+	for idx, label := range output.expectedResult {
+		if label < d.FirstLabel || int(label-d.FirstLabel) >= d.Classes() {
+			panic(fmt.Sprintf("%s: Label %d of image %d out of range", d.Name, label, idx))
+		}
+		output.expectedResult[idx] = label - d.FirstLabel
+	}
+	return output
+}
+
+// Like Import, but the images are read from disk on demand, see OpenData
+func (d Dataset) Open(dir string, imageFile string, labelFile string) (*LazyMNISTData, error) {
+	data, err := OpenData(dir, imageFile, labelFile)
+	if err != nil {
+		return nil, err
+	}
+	if err = data.setDataset(d); err != nil {
+		data.Close()
+		return nil, err
+	}
+	return data, nil
+}
+
+// Like Import, but the images are converted on demand, see LoadData
+func (d Dataset) Load(dir string, imageFile string, labelFile string) (*LazyMNISTData, error) {
+	data, err := LoadData(dir, imageFile, labelFile)
+	if err != nil {
+		return nil, err
+	}
+	if err = data.setDataset(d); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Transpose an image stored as nRows x nCols row by row
+func transposeImage(img []float64, nRows int, nCols int) []float64 {
+	result := make([]float64, len(img))
+	for rowIdx := 0; rowIdx < nRows; rowIdx++ {
+		for colIdx := 0; colIdx < nCols; colIdx++ {
+			result[colIdx*nRows+rowIdx] = img[rowIdx*nCols+colIdx]
+		}
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+package MNISTImport
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+const testDataDir = "../test_data/"
+
+func TestImportTransposed(t *testing.T) {
+	// Arrange
+	dataset := Dataset{Name: "Test", ClassNames: digits}
+	transposed := dataset
+	transposed.Transposed = true
+
+	// Act
+	data := dataset.Import(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	dataT := transposed.Import(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+
+	// Assert
+	for _, rc := range [][]int{{5, 12}, {14, 20}, {3, 27}} {
+		row, col := rc[0], rc[1]
+		if expected := data.inputActivations[0][row*28+col]; dataT.inputActivations[0][col*28+row] != expected {
+			t.Errorf("Pixel (%d, %d) must be %f after transposing, but is %f", row, col, expected, dataT.inputActivations[0][col*28+row])
+		}
+	}
+}
+
+func TestGenerateTrainingSamplesClasses(t *testing.T) {
+	// Arrange
+	data := EMNISTBalanced.Import(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+
+	// Act
+	ts := data.GenerateTrainingSamples(data.Length())
+
+	// Assert
+	if expected := 47; ts[0].OutputActivations.Size() != expected {
+		t.Errorf("Output activations must have size %d, but is %d", expected, ts[0].OutputActivations.Size())
+	}
+	if label := data.GetResult(0); ts[0].OutputActivations.Get(int(label)) != 1 {
+		t.Errorf("Output activation %d must be 1", label)
+	}
+}
+
+func TestImportFirstLabel(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	labels := make([]byte, 8, 8+50)
+	binary.BigEndian.PutUint32(labels[0:4], 0x0801)
+	binary.BigEndian.PutUint32(labels[4:8], 50)
+	for idx := 0; idx < 50; idx++ {
+		labels = append(labels, byte(idx%26+1))
+	}
+	if err := ioutil.WriteFile(path.Join(dir, "labels"), labels, 0644); err != nil {
+		t.Fatal(err)
+	}
+	images, err := ioutil.ReadFile(path.Join(testDataDir, "train-images50.idx3-ubyte"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, "images"), images, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	data := EMNISTLetters.Import(dir, "images", "labels")
+	ts := data.GenerateTrainingSamples(data.Length())
+
+	// Assert
+	if expected := 26; data.Classes() != expected {
+		t.Errorf("Expected %d classes, but got %d", expected, data.Classes())
+	}
+	if label := data.GetResult(27); label != 1 || EMNISTLetters.ClassNames[label] != "b" {
+		t.Errorf("Label 2 must map to class 1 ('b'), but is %d", label)
+	}
+	if ts[25].OutputActivations.Get(25) != 1 {
+		t.Error("Output activation 25 ('z') must be 1")
+	}
+}
+
+func TestDatasetOpen(t *testing.T) {
+	// Arrange, letters labelled 1..26 and transposed images
+	dir := t.TempDir()
+	labels := make([]byte, 8, 8+50)
+	binary.BigEndian.PutUint32(labels[0:4], 0x0801)
+	binary.BigEndian.PutUint32(labels[4:8], 50)
+	for idx := 0; idx < 50; idx++ {
+		labels = append(labels, byte(idx%26+1))
+	}
+	if err := ioutil.WriteFile(path.Join(dir, "labels"), labels, 0644); err != nil {
+		t.Fatal(err)
+	}
+	images, err := ioutil.ReadFile(path.Join(testDataDir, "train-images50.idx3-ubyte"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, "images"), images, 0644); err != nil {
+		t.Fatal(err)
+	}
+	data := EMNISTLetters.Import(dir, "images", "labels")
+	ts := data.GenerateTrainingSamples(data.Length())
+	opened, err := EMNISTLetters.Open(dir, "images", "labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer opened.Close()
+	loaded, err := EMNISTLetters.Load(dir, "images", "labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, lazy := range []*LazyMNISTData{opened, loaded} {
+		// Act
+		var x TrainingSample
+		lazy.Sample(25, &x)
+
+		// Assert
+		if lazy.GetResult(25) != 25 || x.OutputActivations.Size() != 26 || x.OutputActivations.Get(25) != 1 {
+			t.Errorf("Label 26 must map to class 25 ('z'), but is %d", lazy.GetResult(25))
+		}
+		for k := 0; k < ts[25].InputActivations.Size(); k++ {
+			if x.InputActivations.Get(k) != ts[25].InputActivations.Get(k) {
+				t.Fatalf("Input activation %d differs from the transposed import", k)
+			}
+		}
+	}
+	if _, err := EMNISTDigits.Load(dir, "images", "labels"); err == nil {
+		t.Error("Labels beyond the dataset's classes must be rejected")
+	}
+}
@@ -0,0 +1,69 @@
+package MNISTImport
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"math"
+)
+
+// Removes the slant of a digit. The image moments give the covariance of
+// the pixel coordinates, and shearing each row horizontally by
+// alpha (y - c_y), alpha = -mu_11 / mu_02, makes the digit upright while
+// keeping its center of mass in place.
+func Deskew(img []float64, nRows int, nCols int) []float64 {
+	cy, cx := centerOfMass(img, nCols, nRows)
+	var mu11, mu02 float64
+	for row := 0; row < nRows; row++ {
+		for col := 0; col < nCols; col++ {
+			value := img[row*nCols+col]
+			dy := float64(row) - cy
+			mu11 += value * (float64(col) - cx) * dy
+			mu02 += value * dy * dy
+		}
+	}
+	result := make([]float64, len(img))
+	if mu02 == 0 {
+		copy(result, img)
+		return result
+	}
+	alpha := mu11 / mu02
+	for row := 0; row < nRows; row++ {
+		shift := alpha * (float64(row) - cy)
+		for col := 0; col < nCols; col++ {
+			// linear interpolation between the two nearest pixels of the row
+			x := float64(col) + shift
+			x0 := int(math.Floor(x))
+			fx := x - float64(x0)
+			var value float64
+			if x0 >= 0 && x0 < nCols {
+				value += (1 - fx) * img[row*nCols+x0]
+			}
+			if x0+1 >= 0 && x0+1 < nCols {
+				value += fx * img[row*nCols+x0+1]
+			}
+			result[row*nCols+col] = value
+		}
+	}
+	return result
+}
+
+func DeskewVector(v *LinAlg.Vector, nRows int, nCols int) *LinAlg.Vector {
+	img := make([]float64, v.Size())
+	for idx := range img {
+		img[idx] = v.Get(idx)
+	}
+	return LinAlg.MakeVector(Deskew(img, nRows, nCols))
+}
+
+// Deskews all images, i.e. before generating training samples. Inputs at
+// inference must then be deskewed with DeskewVector as well.
+func (data *MNISTData) Deskew() {
+	nRows, nCols := data.nRows, data.nCols
+	for idx, img := range data.inputActivations {
+		if nRows == 0 {
+			// assume square images
+			nRows = int(math.Sqrt(float64(len(img))))
+			nCols = nRows
+		}
+		data.inputActivations[idx] = Deskew(img, nRows, nCols)
+	}
+}
@@ -0,0 +1,64 @@
+package MNISTImport
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"math"
+	"testing"
+)
+
+func skew(img []float64, nRows int, nCols int) float64 {
+	cy, cx := centerOfMass(img, nCols, nRows)
+	var mu11, mu02 float64
+	for row := 0; row < nRows; row++ {
+		for col := 0; col < nCols; col++ {
+			value := img[row*nCols+col]
+			mu11 += value * (float64(col) - cx) * (float64(row) - cy)
+			mu02 += value * (float64(row) - cy) * (float64(row) - cy)
+		}
+	}
+	return mu11 / mu02
+}
+
+func TestDeskew(t *testing.T) {
+	// Arrange
+	data := ImportData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	before := make([]float64, data.Length())
+	for idx := range before {
+		before[idx] = skew(data.inputActivations[idx], 28, 28)
+	}
+
+	// Act
+	data.Deskew()
+
+	// Assert
+	for idx := range before {
+		after := skew(data.inputActivations[idx], 28, 28)
+		if math.Abs(after) > 0.05 && math.Abs(after) > 0.2*math.Abs(before[idx]) {
+			t.Errorf("Image %d: Skew must be reduced, but is %f before and %f after", idx, before[idx], after)
+		}
+	}
+}
+
+func TestDeskewKeepsCenterOfMass(t *testing.T) {
+	// Arrange
+	data := ImportData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	img := data.inputActivations[7]
+
+	// Act
+	deskewed := DeskewVector(createVector(img), 28, 28)
+
+	// Assert
+	result := make([]float64, deskewed.Size())
+	for idx := range result {
+		result[idx] = deskewed.Get(idx)
+	}
+	cy1, cx1 := centerOfMass(img, 28, 28)
+	cy2, cx2 := centerOfMass(result, 28, 28)
+	if math.Abs(cy1-cy2) > 0.1 || math.Abs(cx1-cx2) > 0.1 {
+		t.Errorf("Center of mass must stay at (%f, %f), but is (%f, %f)", cy1, cx1, cy2, cx2)
+	}
+}
+
+func createVector(img []float64) *LinAlg.Vector {
+	return LinAlg.MakeVector(append([]float64(nil), img...))
+}
@@ -0,0 +1,170 @@
+package MNISTImport
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"image"
+	_ "image/jpeg"
+	"math"
+)
+
+const (
+	// MNIST digits are scaled to fit into a 20x20 box, which is then centered
+	// by center of mass in a 28x28 image
+	digitBoxSize = 20
+	DigitSize    = 28
+
+	// pixels below this intensity are considered background when cropping
+	backgroundThreshold = 0.1
+)
+
+// Imports a PNG or JPEG image of a single digit and preprocesses it like
+// the MNIST images, see NormalizeDigitImage
+func ImportDigitImage(fileName string) (*LinAlg.Vector, error) {
+	img, err := readImage(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return NormalizeDigitImage(img), nil
+}
+
+// Converts the image to grayscale, inverts it if the digit is dark on a
+// bright background, crops it to the bounding box of the digit, scales it
+// to fit into 20x20 and centers it by center of mass in a 28x28 image.
+func NormalizeDigitImage(img image.Image) *LinAlg.Vector {
+	bounds := img.Bounds()
+	w := bounds.Dx()
+	h := bounds.Dy()
+	pixels := grayscale(img, false)
+	if borderMean(pixels, w, h) > 0.5 {
+		for idx := range pixels {
+			pixels[idx] = 1 - pixels[idx]
+		}
+	}
+
+	result := LinAlg.MakeEmptyVector(DigitSize * DigitSize)
+	top, left, bottom, right := boundingBox(pixels, w, h)
+	if bottom < top {
+		// no digit found
+		return result
+	}
+	cropped, w, h := crop(pixels, w, top, left, bottom, right)
+
+	// preserve the aspect ratio
+	scale := float64(digitBoxSize) / math.Max(float64(w), float64(h))
+	newW := maxInt(1, int(math.Round(float64(w)*scale)))
+	newH := maxInt(1, int(math.Round(float64(h)*scale)))
+	resized := resize(cropped, w, h, newW, newH)
+
+	// shift the center of mass to the center of the 28x28 image
+	cy, cx := centerOfMass(resized, newW, newH)
+	center := float64(DigitSize-1) / 2
+	offsetY := int(math.Round(center - cy))
+	offsetX := int(math.Round(center - cx))
+	for row := 0; row < newH; row++ {
+		for col := 0; col < newW; col++ {
+			r := row + offsetY
+			c := col + offsetX
+			if r >= 0 && r < DigitSize && c >= 0 && c < DigitSize {
+				result.Set(r*DigitSize+c, resized[row*newW+col])
+			}
+		}
+	}
+	return result
+}
+
+func maxInt(lhs int, rhs int) int {
+	if lhs < rhs {
+		return rhs
+	}
+	return lhs
+}
+
+func borderMean(pixels []float64, w int, h int) float64 {
+	var sum float64
+	var n int
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			if row == 0 || row == h-1 || col == 0 || col == w-1 {
+				sum += pixels[row*w+col]
+				n++
+			}
+		}
+	}
+	return sum / float64(n)
+}
+
+// Returns top > bottom if all pixels are background
+func boundingBox(pixels []float64, w int, h int) (int, int, int, int) {
+	top, left, bottom, right := h, w, -1, -1
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			if pixels[row*w+col] > backgroundThreshold {
+				if row < top {
+					top = row
+				}
+				if row > bottom {
+					bottom = row
+				}
+				if col < left {
+					left = col
+				}
+				if col > right {
+					right = col
+				}
+			}
+		}
+	}
+	return top, left, bottom, right
+}
+
+func crop(pixels []float64, w int, top int, left int, bottom int, right int) ([]float64, int, int) {
+	newW := right - left + 1
+	newH := bottom - top + 1
+	result := make([]float64, newW*newH)
+	for row := 0; row < newH; row++ {
+		copy(result[row*newW:(row+1)*newW], pixels[(top+row)*w+left:(top+row)*w+left+newW])
+	}
+	return result, newW, newH
+}
+
+// Resamples by averaging the area of the source image covered by each
+// target pixel, which anti-aliases when shrinking
+func resize(pixels []float64, w int, h int, newW int, newH int) []float64 {
+	result := make([]float64, newW*newH)
+	sx := float64(w) / float64(newW)
+	sy := float64(h) / float64(newH)
+	for row := 0; row < newH; row++ {
+		y0 := float64(row) * sy
+		y1 := y0 + sy
+		for col := 0; col < newW; col++ {
+			x0 := float64(col) * sx
+			x1 := x0 + sx
+			var sum float64
+			for y := int(y0); y < h && float64(y) < y1; y++ {
+				wy := math.Min(y1, float64(y+1)) - math.Max(y0, float64(y))
+				for x := int(x0); x < w && float64(x) < x1; x++ {
+					wx := math.Min(x1, float64(x+1)) - math.Max(x0, float64(x))
+					sum += wx * wy * pixels[y*w+x]
+				}
+			}
+			result[row*newW+col] = sum / (sx * sy)
+		}
+	}
+	return result
+}
+
+func centerOfMass(pixels []float64, w int, h int) (float64, float64) {
+	var mass, cy, cx float64
+	for row := 0; row < h; row++ {
+		for col := 0; col < w; col++ {
+			value := pixels[row*w+col]
+			mass += value
+			cy += value * float64(row)
+			cx += value * float64(col)
+		}
+	}
+	if mass == 0 {
+		return float64(h-1) / 2, float64(w-1) / 2
+	}
+	return cy / mass, cx / mass
+}
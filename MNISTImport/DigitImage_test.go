@@ -0,0 +1,116 @@
+package MNISTImport
+
+import (
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"os"
+	"path"
+	"testing"
+)
+
+// dark digit-like blob on a bright background, off center
+func createDigitImage() *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, 60, 40))
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 60; x++ {
+			img.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+	for y := 5; y < 35; y++ {
+		for x := 40; x < 50; x++ {
+			img.SetGray(x, y, color.Gray{Y: 0})
+		}
+	}
+	for x := 40; x < 55; x++ {
+		img.SetGray(x, 5, color.Gray{Y: 0})
+	}
+	return img
+}
+
+func TestNormalizeDigitImage(t *testing.T) {
+	// Arrange
+	img := createDigitImage()
+
+	// Act
+	v := NormalizeDigitImage(img)
+
+	// Assert
+	if v.Size() != DigitSize*DigitSize {
+		t.Fatalf("Image must have size %d, but is %d", DigitSize*DigitSize, v.Size())
+	}
+	pixels := make([]float64, v.Size())
+	for idx := range pixels {
+		pixels[idx] = v.Get(idx)
+	}
+	if value := pixels[0]; value != 0 {
+		t.Errorf("Background must be 0 after inverting, but is %f", value)
+	}
+	top, left, bottom, right := boundingBox(pixels, DigitSize, DigitSize)
+	if height := bottom - top + 1; height != digitBoxSize {
+		t.Errorf("Digit must be %d pixels high, but is %d", digitBoxSize, height)
+	}
+	if width := right - left + 1; width > digitBoxSize {
+		t.Errorf("Digit must be at most %d pixels wide, but is %d", digitBoxSize, width)
+	}
+	cy, cx := centerOfMass(pixels, DigitSize, DigitSize)
+	if math.Abs(cy-13.5) > 0.5 || math.Abs(cx-13.5) > 0.5 {
+		t.Errorf("Center of mass must be at (13.5, 13.5), but is (%f, %f)", cy, cx)
+	}
+}
+
+func TestNormalizeEmptyImage(t *testing.T) {
+	// Arrange
+	img := image.NewGray(image.Rect(0, 0, 10, 10))
+
+	// Act
+	v := NormalizeDigitImage(img)
+
+	// Assert
+	for idx := 0; idx < v.Size(); idx++ {
+		if v.Get(idx) != 0 {
+			t.Fatalf("Empty image must give all zero activations")
+		}
+	}
+}
+
+func TestImportDigitImageJPEG(t *testing.T) {
+	// Arrange
+	fileName := path.Join(t.TempDir(), "digit.jpg")
+	file, err := os.Create(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := jpeg.Encode(file, createDigitImage(), nil); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	// Act
+	v, err := ImportDigitImage(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if v.Size() != DigitSize*DigitSize {
+		t.Fatalf("Image must have size %d, but is %d", DigitSize*DigitSize, v.Size())
+	}
+	if value := v.Get(0); value > 0.1 {
+		t.Errorf("Background must be dark after inverting, but is %f", value)
+	}
+}
+
+func TestResize(t *testing.T) {
+	// Arrange
+	pixels := []float64{1, 0, 0, 1, 1, 1, 0, 0}
+
+	// Act
+	r := resize(pixels, 4, 2, 2, 1)
+
+	// Assert
+	if r[0] != 0.75 || r[1] != 0.25 {
+		t.Errorf("Expected [0.75 0.25], but got %v", r)
+	}
+}
@@ -0,0 +1,197 @@
+package MNISTImport
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/png"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+)
+
+// Writes images with pixel values in [0, 1] in the IDX image file format
+func WriteImageFile(w io.Writer, nRows int, nCols int, images [][]float64) error {
+	header := make([]byte, 16)
+	binary.BigEndian.PutUint32(header[0:4], 0x0803)
+	binary.BigEndian.PutUint32(header[4:8], uint32(len(images)))
+	binary.BigEndian.PutUint32(header[8:12], uint32(nRows))
+	binary.BigEndian.PutUint32(header[12:16], uint32(nCols))
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+	for imageIdx, img := range images {
+		if len(img) != nRows*nCols {
+			return fmt.Errorf("Image %d has size %d, but %d expected", imageIdx, len(img), nRows*nCols)
+		}
+		for _, value := range img {
+			if err := bw.WriteByte(toPixel(value)); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+func toPixel(value float64) byte {
+	return byte(math.Round(math.Max(0, math.Min(1, value)) * 255))
+}
+
+// Writes labels in the IDX label file format
+func WriteLabelFile(w io.Writer, labels []byte) error {
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint32(header[0:4], 0x0801)
+	binary.BigEndian.PutUint32(header[4:8], uint32(len(labels)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(labels)
+	return err
+}
+
+func ExportImageFile(fileName string, nRows int, nCols int, images [][]float64) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	err = WriteImageFile(file, nRows, nCols, images)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func ExportLabelFile(fileName string, labels []byte) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	err = WriteLabelFile(file, labels)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (data MNISTData) Export(dir string, imageFile string, labelFile string) error {
+	nRows, nCols := data.nRows, data.nCols
+	if nRows == 0 && data.Length() > 0 {
+		// assume square images
+		nRows = int(math.Sqrt(float64(len(data.inputActivations[0]))))
+		nCols = nRows
+	}
+	err := ExportImageFile(path.Join(dir, imageFile), nRows, nCols, data.inputActivations)
+	if err != nil {
+		return err
+	}
+	return ExportLabelFile(path.Join(dir, labelFile), data.expectedResult)
+}
+
+// Exports training samples, the label of a sample is the index of its
+// largest output activation
+func ExportSamples(dir string, imageFile string, labelFile string, nRows int, nCols int, samples []TrainingSample) error {
+	data := MNISTData{inputActivations: make([][]float64, len(samples)), expectedResult: make([]byte, len(samples)), nRows: nRows, nCols: nCols}
+	for idx := range samples {
+		x := &samples[idx].InputActivations
+		img := make([]float64, x.Size())
+		for k := range img {
+			img[k] = x.Get(k)
+		}
+		data.inputActivations[idx] = img
+
+		y := &samples[idx].OutputActivations
+		var label int
+		for k := 0; k < y.Size(); k++ {
+			if y.Get(k) > y.Get(label) {
+				label = k
+			}
+		}
+		data.expectedResult[idx] = byte(label)
+	}
+	return data.Export(dir, imageFile, labelFile)
+}
+
+// Imports labelled PNG images from the subdirectories of 'dir', where the name
+// of each subdirectory is the label of the images it contains, i.e. dir/3/a.png.
+// All images must have the same size. MNIST digits are white on black, so
+// black on white images must be inverted.
+func ImportImageDirectory(dir string, invert bool) (MNISTData, error) {
+	var output MNISTData
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return output, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() == false {
+			continue
+		}
+		label, err := strconv.Atoi(entry.Name())
+		if err != nil || label < 0 || label > 255 {
+			return output, fmt.Errorf("Directory name %s is not a label", entry.Name())
+		}
+		files, err := ioutil.ReadDir(path.Join(dir, entry.Name()))
+		if err != nil {
+			return output, err
+		}
+		sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+		for _, file := range files {
+			if file.IsDir() || path.Ext(file.Name()) != ".png" {
+				continue
+			}
+			fileName := path.Join(dir, entry.Name(), file.Name())
+			img, err := readImage(fileName)
+			if err != nil {
+				return output, err
+			}
+			bounds := img.Bounds()
+			if output.Length() == 0 {
+				output.nRows, output.nCols = bounds.Dy(), bounds.Dx()
+			} else if bounds.Dy() != output.nRows || bounds.Dx() != output.nCols {
+				return output, fmt.Errorf("Image %s has size %dx%d, but %dx%d expected", fileName, bounds.Dy(), bounds.Dx(), output.nRows, output.nCols)
+			}
+			output.inputActivations = append(output.inputActivations, grayscale(img, invert))
+			output.expectedResult = append(output.expectedResult, byte(label))
+			if label+1 > output.Classes() {
+				output.nClasses = label + 1
+			}
+		}
+	}
+	return output, nil
+}
+
+func readImage(fileName string) (image.Image, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", fileName, err)
+	}
+	return img, nil
+}
+
+// Pixel intensities in [0, 1], row by row
+func grayscale(img image.Image, invert bool) []float64 {
+	bounds := img.Bounds()
+	output := make([]float64, bounds.Dx()*bounds.Dy())
+	for rowIdx := 0; rowIdx < bounds.Dy(); rowIdx++ {
+		for colIdx := 0; colIdx < bounds.Dx(); colIdx++ {
+			gray := color.Gray16Model.Convert(img.At(bounds.Min.X+colIdx, bounds.Min.Y+rowIdx)).(color.Gray16)
+			value := float64(gray.Y) / 0xffff
+			if invert {
+				value = 1 - value
+			}
+			output[rowIdx*bounds.Dx()+colIdx] = value
+		}
+	}
+	return output
+}
@@ -0,0 +1,112 @@
+package MNISTImport
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	data := ImportData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+
+	// Act
+	err := data.Export(dir, "images", "labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	tables := []struct {
+		original string
+		exported string
+		size     int
+	}{
+		{"train-images50.idx3-ubyte", "images", 16 + 50*28*28},
+		// the label fixture has a trailing byte after the 50 labels
+		{"train-labels50.idx1-ubyte", "labels", 8 + 50},
+	}
+	for _, item := range tables {
+		expected, err := ioutil.ReadFile(path.Join(testDataDir, item.original))
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := ioutil.ReadFile(path.Join(dir, item.exported))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(expected[:item.size], actual) == false {
+			t.Errorf("Exported file %s differs from %s", item.exported, item.original)
+		}
+	}
+}
+
+func TestExportSamples(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	data := ImportData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	ts := data.GenerateTrainingSamples(5)
+
+	// Act
+	err := ExportSamples(dir, "images", "labels", 28, 28, ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported := ImportData(dir, "images", "labels")
+
+	// Assert
+	if exported.Length() != 5 {
+		t.Fatalf("Expected 5 images, but got %d", exported.Length())
+	}
+	for idx := 0; idx < 5; idx++ {
+		if exported.GetResult(idx) != data.GetResult(idx) {
+			t.Errorf("Image %d: Expected label %d, but got %d", idx, data.GetResult(idx), exported.GetResult(idx))
+		}
+	}
+}
+
+func TestImportImageDirectory(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	for _, label := range []string{"3", "7"} {
+		if err := os.Mkdir(path.Join(dir, label), 0755); err != nil {
+			t.Fatal(err)
+		}
+		img := image.NewGray(image.Rect(0, 0, 4, 3))
+		img.SetGray(1, 2, color.Gray{Y: 51})
+		file, err := os.Create(path.Join(dir, label, "digit.png"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := png.Encode(file, img); err != nil {
+			t.Fatal(err)
+		}
+		file.Close()
+	}
+
+	// Act
+	data, err := ImportImageDirectory(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if data.Length() != 2 {
+		t.Fatalf("Expected 2 images, but got %d", data.Length())
+	}
+	if nRows, nCols := data.ImageSize(); nRows != 3 || nCols != 4 {
+		t.Errorf("Expected image size 3x4, but got %dx%d", nRows, nCols)
+	}
+	if data.GetResult(0) != 3 || data.GetResult(1) != 7 {
+		t.Errorf("Expected labels 3 and 7, but got %d and %d", data.GetResult(0), data.GetResult(1))
+	}
+	if value := data.inputActivations[1][2*4+1]; value != 0.2 {
+		t.Errorf("Pixel (2, 1) must be 0.2, but is %f", value)
+	}
+}
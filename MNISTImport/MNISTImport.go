@@ -15,6 +15,13 @@ import (
 type MNISTData struct {
 	inputActivations [][]float64
 	expectedResult   []byte
+
+	// number of classes, 10 if not set
+	nClasses int
+
+	// image size
+	nRows int
+	nCols int
 }
 
 func init() {
@@ -29,7 +36,7 @@ func BuildFromImageFile(nImages int, nRows int, nCols int, data []byte) [][]floa
 		output[imageIdx] = img
 		m := image.NewRGBA(image.Rect(0, 0, nCols, nRows))
 		for rowIdx := 0; rowIdx < nRows; rowIdx++ {
-			for colIdx := 0; colIdx < nRows; colIdx++ {
+			for colIdx := 0; colIdx < nCols; colIdx++ {
 				value := data[idx]
 				idx++
 				img[rowIdx*nCols+colIdx] = float64(value) / 255
@@ -56,6 +63,11 @@ func BuildFromLabelFile(nLabels int, data []byte) []byte {
 }
 
 func ImportImageFile(fileName string) [][]float64 {
+	images, _, _ := importImageFile(fileName)
+	return images
+}
+
+func importImageFile(fileName string) ([][]float64, int, int) {
 	data, err := ioutil.ReadFile(fileName)
 	if err != nil {
 		panic("Failed to read data")
@@ -67,7 +79,7 @@ func ImportImageFile(fileName string) [][]float64 {
 	nImages := int(binary.BigEndian.Uint32(data[4:8]))
 	nRows := int(binary.BigEndian.Uint32(data[8:12]))
 	nCols := int(binary.BigEndian.Uint32(data[12:16]))
-	return BuildFromImageFile(nImages, nRows, nCols, data[16:])
+	return BuildFromImageFile(nImages, nRows, nCols, data[16:]), nRows, nCols
 }
 
 func ImportLabelFile(fileName string) []byte {
@@ -85,7 +97,7 @@ func ImportLabelFile(fileName string) []byte {
 
 func ImportData(dir string, imageFile string, labelFile string) MNISTData {
 	var output MNISTData
-	output.inputActivations = ImportImageFile(path.Join(dir, imageFile))
+	output.inputActivations, output.nRows, output.nCols = importImageFile(path.Join(dir, imageFile))
 	output.expectedResult = ImportLabelFile(path.Join(dir, labelFile))
 	return output
 }
@@ -94,6 +106,17 @@ func (data MNISTData) Length() int {
 	return len(data.inputActivations)
 }
 
+func (data MNISTData) ImageSize() (int, int) {
+	return data.nRows, data.nCols
+}
+
+func (data MNISTData) Classes() int {
+	if data.nClasses == 0 {
+		return 10
+	}
+	return data.nClasses
+}
+
 func (data MNISTData) GenerateTrainingSamples(length int) []TrainingSample {
 	tss := make([]TrainingSample, length)
 	for idx := range data.inputActivations {
@@ -102,7 +125,7 @@ func (data MNISTData) GenerateTrainingSamples(length int) []TrainingSample {
 		}
 		ts := &tss[idx]
 		ts.InputActivations = *LinAlg.MakeVector(data.inputActivations[idx])
-		ts.OutputActivations = *LinAlg.MakeEmptyVector(10)
+		ts.OutputActivations = *LinAlg.MakeEmptyVector(data.Classes())
 		expectedResult := data.expectedResult[idx]
 		ts.OutputActivations.Set(int(expectedResult), 1)
 	}
@@ -124,7 +147,7 @@ func (data *MNISTData) Split(ratio float32, size int) (*MNISTData, *MNISTData) {
 	perm := rand.Perm(totalSize)
 
 	var GenerateData = func(size int, offset int) *MNISTData {
-		newData := &MNISTData{make([][]float64, size), make([]byte, size)}
+		newData := &MNISTData{inputActivations: make([][]float64, size), expectedResult: make([]byte, size), nClasses: data.nClasses, nRows: data.nRows, nCols: data.nCols}
 		for idx := 0; idx < size; idx++ {
 			dataIdx := perm[offset+idx]
 			newData.inputActivations[idx] = data.inputActivations[dataIdx]
@@ -141,3 +164,68 @@ func (data *MNISTData) Split(ratio float32, size int) (*MNISTData, *MNISTData) {
 
 	return trainingData, validationData
 }
+
+// Indices of the samples, grouped by class and shuffled
+func (data *MNISTData) shuffledClassIndices(rng *rand.Rand) [][]int {
+	classes := make([][]int, data.Classes())
+	for _, idx := range rng.Perm(data.Length()) {
+		label := data.expectedResult[idx]
+		classes[label] = append(classes[label], idx)
+	}
+	return classes
+}
+
+func (data *MNISTData) subset(indices []int) *MNISTData {
+	newData := &MNISTData{inputActivations: make([][]float64, len(indices)), expectedResult: make([]byte, len(indices)), nClasses: data.nClasses, nRows: data.nRows, nCols: data.nCols}
+	for idx, dataIdx := range indices {
+		newData.inputActivations[idx] = data.inputActivations[dataIdx]
+		newData.expectedResult[idx] = data.expectedResult[dataIdx]
+	}
+	return newData
+}
+
+// Like Split, but uses all data and preserves the proportion of each class in
+// both the training and validation data. The same seed gives the same split.
+func (data *MNISTData) StratifiedSplit(ratio float32, seed int64) (*MNISTData, *MNISTData) {
+	if ratio <= 0 || ratio > 1 {
+		panic(fmt.Sprintf("Ratio %f must be between (0,1]", ratio))
+	}
+	rng := rand.New(rand.NewSource(seed))
+	var trainingIndices, validationIndices []int
+	for _, indices := range data.shuffledClassIndices(rng) {
+		validationSize := int(float32(len(indices))*ratio + 0.5)
+		validationIndices = append(validationIndices, indices[:validationSize]...)
+		trainingIndices = append(trainingIndices, indices[validationSize:]...)
+	}
+	return data.subset(trainingIndices), data.subset(validationIndices)
+}
+
+// Partitions the sample indices into k folds of (almost) equal size, each
+// with the class proportions of the whole data set. The same seed gives the
+// same folds.
+func (data *MNISTData) StratifiedFolds(k int, seed int64) [][]int {
+	if k < 2 || k > data.Length() {
+		panic(fmt.Sprintf("Number of folds %d must be between 2 and the data size %d", k, data.Length()))
+	}
+	rng := rand.New(rand.NewSource(seed))
+	folds := make([][]int, k)
+	var fold int
+	for _, indices := range data.shuffledClassIndices(rng) {
+		for _, idx := range indices {
+			folds[fold] = append(folds[fold], idx)
+			fold = (fold + 1) % k
+		}
+	}
+	return folds
+}
+
+// Returns the training data, all folds but 'fold', and the validation data, fold 'fold'
+func (data *MNISTData) Fold(folds [][]int, fold int) (*MNISTData, *MNISTData) {
+	var trainingIndices []int
+	for idx := range folds {
+		if idx != fold {
+			trainingIndices = append(trainingIndices, folds[idx]...)
+		}
+	}
+	return data.subset(trainingIndices), data.subset(folds[fold])
+}
@@ -9,3 +9,67 @@ func TestImportImageFile(t *testing.T) {
 func TestImportLabelFile(t *testing.T) {
 	ImportLabelFile("/home/svenschmidt75/Develop/Go/MNIST/train-labels.idx1-ubyte")
 }
+
+func classCounts(data *MNISTData) []int {
+	counts := make([]int, data.Classes())
+	for idx := 0; idx < data.Length(); idx++ {
+		counts[data.GetResult(idx)]++
+	}
+	return counts
+}
+
+func TestStratifiedSplit(t *testing.T) {
+	// Arrange
+	data := ImportData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	counts := classCounts(&data)
+
+	// Act
+	trainingData, validationData := data.StratifiedSplit(0.2, 42)
+	trainingData2, _ := data.StratifiedSplit(0.2, 42)
+
+	// Assert
+	if total := trainingData.Length() + validationData.Length(); total != data.Length() {
+		t.Errorf("Split must use all %d samples, but uses %d", data.Length(), total)
+	}
+	validationCounts := classCounts(validationData)
+	for label, count := range counts {
+		if expected := int(float32(count)*0.2 + 0.5); validationCounts[label] != expected {
+			t.Errorf("Expected %d validation samples of class %d, but got %d", expected, label, validationCounts[label])
+		}
+	}
+	for idx := 0; idx < trainingData.Length(); idx++ {
+		if trainingData.GetResult(idx) != trainingData2.GetResult(idx) || &trainingData.inputActivations[idx][0] != &trainingData2.inputActivations[idx][0] {
+			t.Fatal("Split with same seed must be identical")
+		}
+	}
+}
+
+func TestStratifiedFolds(t *testing.T) {
+	// Arrange
+	data := ImportData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	k := 5
+
+	// Act
+	folds := data.StratifiedFolds(k, 7)
+
+	// Assert
+	seen := make(map[int]bool)
+	for fold := range folds {
+		if size := len(folds[fold]); size != data.Length()/k {
+			t.Errorf("Fold %d must have %d samples, but has %d", fold, data.Length()/k, size)
+		}
+		for _, idx := range folds[fold] {
+			if seen[idx] {
+				t.Errorf("Sample %d is in more than one fold", idx)
+			}
+			seen[idx] = true
+		}
+	}
+	if len(seen) != data.Length() {
+		t.Errorf("Folds must cover all %d samples, but cover %d", data.Length(), len(seen))
+	}
+	trainingData, validationData := data.Fold(folds, 2)
+	if trainingData.Length() != 40 || validationData.Length() != 10 {
+		t.Errorf("Unexpected fold sizes %d and %d", trainingData.Length(), validationData.Length())
+	}
+}
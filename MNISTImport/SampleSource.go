@@ -0,0 +1,179 @@
+package MNISTImport
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+)
+
+// Training samples accessed by index. Lets training run on data sets that
+// are materialized on demand instead of held in memory as float64 vectors.
+type SampleSource interface {
+	Length() int
+
+	// Writes sample 'index' into 'ts', reusing its vectors where possible
+	Sample(index int, ts *TrainingSample)
+}
+
+// Adapts in-memory training samples to SampleSource
+type TrainingSamples []TrainingSample
+
+func (samples TrainingSamples) Length() int {
+	return len(samples)
+}
+
+func (samples TrainingSamples) Sample(index int, ts *TrainingSample) {
+	*ts = samples[index]
+}
+
+// MNIST data that keeps the raw pixel bytes on disk (OpenData) or in memory
+// (LoadData), and converts an image to float64 only when it is requested.
+// Not safe for concurrent use.
+type LazyMNISTData struct {
+	images io.ReaderAt
+	closer io.Closer
+	labels []byte
+
+	nImages int
+	nRows   int
+	nCols   int
+
+	// number of classes, 10 if not set
+	nClasses int
+
+	// label of class 0, and whether images are stored column by column,
+	// see Dataset
+	firstLabel byte
+	transposed bool
+
+	// raw pixels of the last image read
+	buffer []byte
+}
+
+func OpenData(dir string, imageFile string, labelFile string) (*LazyMNISTData, error) {
+	file, err := os.Open(path.Join(dir, imageFile))
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, 16)
+	if _, err = file.ReadAt(header, 0); err != nil {
+		file.Close()
+		return nil, err
+	}
+	data, err := createLazyData(header, file, path.Join(dir, labelFile))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	data.closer = file
+	return data, nil
+}
+
+func LoadData(dir string, imageFile string, labelFile string) (*LazyMNISTData, error) {
+	images, err := ioutil.ReadFile(path.Join(dir, imageFile))
+	if err != nil {
+		return nil, err
+	}
+	if len(images) < 16 {
+		return nil, fmt.Errorf("Image file format error")
+	}
+	return createLazyData(images[:16], bytes.NewReader(images), path.Join(dir, labelFile))
+}
+
+func createLazyData(header []byte, images io.ReaderAt, labelFile string) (*LazyMNISTData, error) {
+	if magicNumber := binary.BigEndian.Uint32(header[0:4]); magicNumber != 0x0803 {
+		return nil, fmt.Errorf("Image file format error")
+	}
+	data := &LazyMNISTData{images: images}
+	data.nImages = int(binary.BigEndian.Uint32(header[4:8]))
+	data.nRows = int(binary.BigEndian.Uint32(header[8:12]))
+	data.nCols = int(binary.BigEndian.Uint32(header[12:16]))
+	data.buffer = make([]byte, data.nRows*data.nCols)
+
+	labels, err := ioutil.ReadFile(labelFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) < 8 || binary.BigEndian.Uint32(labels[0:4]) != 0x0801 {
+		return nil, fmt.Errorf("Label file format error")
+	}
+	nLabels := int(binary.BigEndian.Uint32(labels[4:8]))
+	if nLabels != data.nImages || len(labels) < 8+nLabels {
+		return nil, fmt.Errorf("Label file has %d labels, but image file %d images", nLabels, data.nImages)
+	}
+	data.labels = labels[8 : 8+nLabels]
+	return data, nil
+}
+
+func (data *LazyMNISTData) Close() error {
+	if data.closer == nil {
+		return nil
+	}
+	return data.closer.Close()
+}
+
+func (data *LazyMNISTData) Length() int {
+	return data.nImages
+}
+
+func (data *LazyMNISTData) Classes() int {
+	if data.nClasses == 0 {
+		return 10
+	}
+	return data.nClasses
+}
+
+func (data *LazyMNISTData) SetClasses(nClasses int) {
+	data.nClasses = nClasses
+}
+
+// Class of sample 'index', i.e. the label minus the dataset's first label
+func (data *LazyMNISTData) GetResult(index int) byte {
+	return data.labels[index] - data.firstLabel
+}
+
+// Applies the label offset and orientation of 'd', and checks that all
+// labels denote one of its classes
+func (data *LazyMNISTData) setDataset(d Dataset) error {
+	data.nClasses = d.Classes()
+	data.firstLabel = d.FirstLabel
+	data.transposed = d.Transposed
+	for idx, label := range data.labels {
+		if label < d.FirstLabel || int(label-d.FirstLabel) >= d.Classes() {
+			return fmt.Errorf("%s: Label %d of image %d out of range", d.Name, label, idx)
+		}
+	}
+	return nil
+}
+
+func (data *LazyMNISTData) Sample(index int, ts *TrainingSample) {
+	size := data.nRows * data.nCols
+	offset := int64(16 + index*size)
+	if _, err := data.images.ReadAt(data.buffer, offset); err != nil {
+		panic(fmt.Sprintf("Failed to read image %d: %v", index, err))
+	}
+	if ts.InputActivations.Size() != size {
+		ts.InputActivations = *LinAlg.MakeEmptyVector(size)
+	}
+	for idx, value := range data.buffer {
+		if data.transposed {
+			// like transposeImage
+			row, col := idx/data.nCols, idx%data.nCols
+			ts.InputActivations.Set(col*data.nRows+row, float64(value)/255)
+			continue
+		}
+		ts.InputActivations.Set(idx, float64(value)/255)
+	}
+	if ts.OutputActivations.Size() != data.Classes() {
+		ts.OutputActivations = *LinAlg.MakeEmptyVector(data.Classes())
+	}
+	for idx := 0; idx < data.Classes(); idx++ {
+		ts.OutputActivations.Set(idx, 0)
+	}
+	ts.OutputActivations.Set(int(data.GetResult(index)), 1)
+}
@@ -0,0 +1,72 @@
+package MNISTImport
+
+import "testing"
+
+func TestLazyMNISTData(t *testing.T) {
+	// Arrange
+	data := ImportData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	ts := data.GenerateTrainingSamples(data.Length())
+	opened, err := OpenData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer opened.Close()
+	loaded, err := LoadData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, lazy := range []*LazyMNISTData{opened, loaded} {
+		// Act
+		var x TrainingSample
+		if lazy.Length() != data.Length() {
+			t.Fatalf("Expected %d samples, but got %d", data.Length(), lazy.Length())
+		}
+		for _, idx := range []int{0, 17, 49, 3} {
+			lazy.Sample(idx, &x)
+
+			// Assert
+			if lazy.GetResult(idx) != data.GetResult(idx) {
+				t.Errorf("Sample %d: Expected label %d, but got %d", idx, data.GetResult(idx), lazy.GetResult(idx))
+			}
+			for k := 0; k < ts[idx].InputActivations.Size(); k++ {
+				if x.InputActivations.Get(k) != ts[idx].InputActivations.Get(k) {
+					t.Fatalf("Sample %d: Input activation %d differs", idx, k)
+				}
+			}
+			for k := 0; k < ts[idx].OutputActivations.Size(); k++ {
+				if x.OutputActivations.Get(k) != ts[idx].OutputActivations.Get(k) {
+					t.Fatalf("Sample %d: Output activation %d differs", idx, k)
+				}
+			}
+		}
+	}
+}
+
+func TestTrainingSamplesSource(t *testing.T) {
+	// Arrange
+	data := ImportData(testDataDir, "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	ts := TrainingSamples(data.GenerateTrainingSamples(data.Length()))
+
+	// Act
+	var x TrainingSample
+	ts.Sample(5, &x)
+
+	// Assert
+	if ts.Length() != data.Length() {
+		t.Errorf("Expected %d samples, but got %d", data.Length(), ts.Length())
+	}
+	if x.OutputActivations.Get(int(data.GetResult(5))) != 1 {
+		t.Error("Wrong sample returned")
+	}
+}
+
+func TestOpenDataMissingFile(t *testing.T) {
+	// Act
+	_, err := OpenData(testDataDir, "missing", "train-labels50.idx1-ubyte")
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for missing image file")
+	}
+}
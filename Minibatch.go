@@ -10,13 +10,21 @@ type Minibatch struct {
 
 	// errors
 	delta []LinAlg.Vector
+
+	// scratch space for sigma'(z) during backpropagation
+	s []LinAlg.Vector
+
+	// dense copy of a sparse input, a[0] points here after FeedforwardSparse
+	input LinAlg.Vector
 }
 
 func CreateMiniBatch(layers []int) Minibatch {
 	z := createVectors(layers)
 	a := createVectors(layers)
 	delta := createVectors(layers)
-	return Minibatch{z, a, delta}
+	s := createVectors(layers)
+	input := *LinAlg.MakeEmptyVector(layers[0])
+	return Minibatch{z, a, delta, s, input}
 }
 
 func CreateMiniBatches(size int, layers []int) []Minibatch {
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"SimpleNeuralNet/Augmentation"
 	"SimpleNeuralNet/LinAlg"
 	"SimpleNeuralNet/MNISTImport"
+	"SimpleNeuralNet/Preprocessing"
 	"bytes"
 	"encoding/gob"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
 	"time"
@@ -24,6 +27,12 @@ type Network struct {
 
 	// Weight matrices. w_{ij}^l connects a_i^l with a_j^{l-1}
 	weights []LinAlg.Matrix
+
+	// applied to raw input activations by Predict
+	preprocessor *Preprocessing.Preprocessor
+
+	// perturbs the training samples in each epoch, not serialized
+	augmenter *Augmentation.Augmenter
 }
 
 func init() {
@@ -48,6 +57,16 @@ func (n *Network) GobEncode() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = encoder.Encode(n.preprocessor != nil)
+	if err != nil {
+		return nil, err
+	}
+	if n.preprocessor != nil {
+		err = encoder.Encode(n.preprocessor)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return w.Bytes(), nil
 }
 
@@ -65,7 +84,73 @@ func (n *Network) GobDecode(buf []byte) error {
 	if err != nil {
 		return err
 	}
-	return decoder.Decode(&n.weights)
+	err = decoder.Decode(&n.weights)
+	if err != nil {
+		return err
+	}
+	err = n.validate("Network.GobDecode")
+	if err != nil {
+		return err
+	}
+	n.preprocessor, err = decodePreprocessor("Network.GobDecode", decoder, n.nodes)
+	return err
+}
+
+// Checks that the decoded weights and biases match the layer sizes
+func (n *Network) validate(op string) error {
+	biasSizes := make([]int, len(n.biases))
+	for layer := range n.biases {
+		biasSizes[layer] = n.biases[layer].Size()
+	}
+	weightShapes := make([][2]int, len(n.weights))
+	for layer := range n.weights {
+		weightShapes[layer] = [2]int{n.weights[layer].Rows, n.weights[layer].Cols}
+	}
+	return validateLayers(op, n.nodes, biasSizes, weightShapes)
+}
+
+// Checks that per layer bias sizes and weight shapes (rows, cols) match the
+// number of nodes. Shared by the double and single precision networks.
+func validateLayers(op string, nodes []int, biasSizes []int, weightShapes [][2]int) error {
+	if len(biasSizes) != len(nodes) || len(weightShapes) != len(nodes) {
+		return &LinAlg.DimensionError{Op: op, Message: fmt.Sprintf("%d layers, but %d bias vectors and %d weight matrices", len(nodes), len(biasSizes), len(weightShapes))}
+	}
+	for layer := 1; layer < len(nodes); layer++ {
+		if _, err := LinAlg.CheckShape(op, nodes[layer], nodes[layer-1]); err != nil {
+			return err
+		}
+		w := weightShapes[layer]
+		if w[0] != nodes[layer] || w[1] != nodes[layer-1] {
+			return &LinAlg.DimensionError{Op: op, Message: fmt.Sprintf("Weights of layer %d are %dx%d, but %dx%d expected", layer, w[0], w[1], nodes[layer], nodes[layer-1])}
+		}
+		if biasSizes[layer] != nodes[layer] {
+			return &LinAlg.DimensionError{Op: op, Message: fmt.Sprintf("Biases of layer %d have size %d, but %d expected", layer, biasSizes[layer], nodes[layer])}
+		}
+	}
+	return nil
+}
+
+// Decodes the optional preprocessor that follows the layers, and checks that
+// it matches the input layer
+func decodePreprocessor(op string, decoder *gob.Decoder, nodes []int) (*Preprocessing.Preprocessor, error) {
+	// networks serialized before preprocessing was added end here
+	var hasPreprocessor bool
+	err := decoder.Decode(&hasPreprocessor)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil || hasPreprocessor == false {
+		return nil, err
+	}
+	preprocessor := new(Preprocessing.Preprocessor)
+	err = decoder.Decode(preprocessor)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 || preprocessor.Size() != nodes[0] {
+		return nil, &LinAlg.DimensionError{Op: op, Message: fmt.Sprintf("Preprocessor has input size %d, but the network has layers %v", preprocessor.Size(), nodes)}
+	}
+	return preprocessor, nil
 }
 
 func CreateNetwork(layers []int) Network {
@@ -114,16 +199,27 @@ func (n *Network) SetWeights(layer int, w *LinAlg.Matrix) {
 	n.weights[layer] = *w
 }
 
+func (n *Network) GetPreprocessor() *Preprocessing.Preprocessor {
+	return n.preprocessor
+}
+
+// The preprocessor must have been fitted to, and applied to, the training
+// samples. It is serialized with the network and applied by Predict.
+func (n *Network) SetPreprocessor(preprocessor *Preprocessing.Preprocessor) {
+	n.preprocessor = preprocessor
+}
+
+// The augmenter works on raw images with pixels in [0, 1], so it cannot be
+// combined with a preprocessor other than MinMax: the training samples are
+// already preprocessed and would be clamped to [0, 1]. Training panics then.
+func (n *Network) SetAugmenter(augmenter *Augmentation.Augmenter) {
+	n.augmenter = augmenter
+}
+
 func (n *Network) weightsSquared() float64 {
 	var l2 float64
 	for layer := range n.GetLayers() {
-		w := n.GetWeights(layer)
-		for row := 0; row < w.Rows; row++ {
-			for col := 0; col < w.Cols; col++ {
-				v := w.Get(row, col)
-				l2 += v * v
-			}
-		}
+		l2 += n.GetWeights(layer).SumOfSquares()
 	}
 	return l2
 }
@@ -144,12 +240,13 @@ func SigmoidPrime(z float64) float64 {
 }
 
 func (n *Network) CalculateZ(layer int, mb *Minibatch) {
-	mb.z[layer] = *LinAlg.AddVectors(n.GetWeights(layer).Ax(&mb.a[layer-1]), n.GetBias(layer))
+	z := &mb.z[layer]
+	LinAlg.MulVecTo(z, n.GetWeights(layer), &mb.a[layer-1]).Add(n.GetBias(layer))
 }
 
 func (n *Network) FeedforwardLayer(layer int, mb *Minibatch) {
 	n.CalculateZ(layer, mb)
-	mb.a[layer] = *mb.z[layer].F(Sigmoid)
+	LinAlg.FTo(&mb.a[layer], &mb.z[layer], Sigmoid)
 }
 
 func (n *Network) Feedforward(mb *Minibatch) {
@@ -161,21 +258,74 @@ func (n *Network) Feedforward(mb *Minibatch) {
 	}
 }
 
-func (n *Network) InitializeNetworkWeightsAndBiases() {
+// Like Feedforward, but the first layer only visits the weights of non-zero
+// input activations. mb.a[0] is set to the dense input for backpropagation;
+// it is expanded into the minibatch's own buffer, so that the vector a[0]
+// pointed to before, i.e. a training sample, is left untouched.
+func (n *Network) FeedforwardSparse(input *LinAlg.SparseVector, mb *Minibatch) {
+	mb.a[0] = *input.ToDenseTo(&mb.input)
 	for layer := range n.nodes {
 		if layer == 0 {
 			continue
 		}
-		w := n.weights[layer]
-		for row := 0; row < w.Rows; row++ {
-			for col := 0; col < w.Cols; col++ {
-				w.Set(row, col, rand.Float64()/100.0)
-			}
+		if layer == 1 {
+			z := LinAlg.MulSparseVecTo(&mb.z[1], n.GetWeights(1), input).Add(n.GetBias(1))
+			LinAlg.FTo(&mb.a[1], z, Sigmoid)
+			continue
+		}
+		n.FeedforwardLayer(layer, mb)
+	}
+}
+
+// Feeds a whole minibatch through the network as matrix operations. Each
+// column of 'inputs' is one (preprocessed) sample; the result holds the
+// output layer activations of the samples in the same column order.
+func (n *Network) FeedforwardBatch(inputs *LinAlg.Matrix) *LinAlg.Matrix {
+	a := inputs
+	for layer := range n.nodes {
+		if layer == 0 {
+			continue
 		}
-		b := n.biases[layer]
-		for row := 0; row < b.Size(); row++ {
-			b.Set(row, rand.Float64()/100.0)
+		a = n.GetWeights(layer).Am(a).AddColVector(n.GetBias(layer)).Apply(Sigmoid)
+	}
+	return a
+}
+
+// Returns the output layer activations for raw, not preprocessed input activations
+func (n *Network) Predict(input *LinAlg.Vector) *LinAlg.Vector {
+	mb := CreateMiniBatch(n.nodes)
+	mb.a[0] = *input
+	if n.preprocessor != nil {
+		mb.a[0] = *n.preprocessor.Transform(input)
+	}
+	n.Feedforward(&mb)
+	return n.GetOutputLayerActivations(&mb)
+}
+
+// Returns the predicted class for raw input activations, and the output layer
+// activations normalized to probabilities
+func (n *Network) Classify(input *LinAlg.Vector) (int, []float64) {
+	a := n.Predict(input)
+	probabilities := make([]float64, a.Size())
+	sum := a.Sum()
+	for idx := range probabilities {
+		probabilities[idx] = a.Get(idx) / sum
+	}
+	return GetClass(a), probabilities
+}
+
+func (n *Network) InitializeNetworkWeightsAndBiases() {
+	n.InitializeNetworkWeightsAndBiasesFrom(rand.New(rand.NewSource(rand.Int63())))
+}
+
+// Like InitializeNetworkWeightsAndBiases, but reproducible for a seeded source
+func (n *Network) InitializeNetworkWeightsAndBiasesFrom(rng *rand.Rand) {
+	for layer := range n.nodes {
+		if layer == 0 {
+			continue
 		}
+		n.SetWeights(layer, LinAlg.RandomUniformMatrix(n.nodes[layer], n.nodes[layer-1], 0, 0.01, rng))
+		n.SetBias(layer, LinAlg.RandomUniformVector(n.nodes[layer], 0, 0.01, rng))
 	}
 }
 
@@ -183,10 +333,10 @@ func (n *Network) BackpropagateError(mb *Minibatch) {
 	// Equation (45), Chapter 2 of http://neuralnetworksanddeeplearning.com
 	outputLayerIdx := n.getOutputLayerIndex()
 	for layer := outputLayerIdx - 1; layer > 0; layer-- {
-		delta_next := mb.delta[layer+1]
-		s := mb.z[layer].F(SigmoidPrime)
-		delta := n.GetWeights(layer + 1).Transpose().Ax(&delta_next).Hadamard(s)
-		mb.delta[layer] = *delta
+		delta := &mb.delta[layer]
+		s := LinAlg.FTo(&mb.s[layer], &mb.z[layer], SigmoidPrime)
+		LinAlg.MulTransVecTo(delta, n.GetWeights(layer+1), &mb.delta[layer+1])
+		LinAlg.HadamardTo(delta, delta, s)
 	}
 }
 
@@ -213,33 +363,49 @@ func (n *Network) CalculateDerivatives(mbs []Minibatch) ([]LinAlg.Matrix, []LinA
 	 * and \frac{\partial C_{x}}{\partial b_{j}^{l}} = a_{k}^{l-1, x} \delta_{j}^{l, x}
 	 */
 
+	dw, db := n.createDerivatives()
+	n.calculateDerivativesTo(dw, db, mbs)
+	return dw, db
+}
+
+func (n *Network) createDerivatives() ([]LinAlg.Matrix, []LinAlg.Vector) {
 	// d C_x / d_wjk^l
 	dw := make([]LinAlg.Matrix, n.getOutputLayerIndex()+1)
 
 	// d C_x / d_bj^l
 	db := make([]LinAlg.Vector, n.getOutputLayerIndex()+1)
 
+	for layer := range n.nodes {
+		if layer == 0 {
+			continue
+		}
+		dw[layer] = *LinAlg.MakeEmptyMatrix(n.nodes[layer], n.nodes[layer-1])
+		db[layer] = *LinAlg.MakeEmptyVector(n.nodes[layer])
+	}
+	return dw, db
+}
+
+// Like CalculateDerivatives, but overwrites dw and db instead of allocating them
+func (n *Network) calculateDerivativesTo(dw []LinAlg.Matrix, db []LinAlg.Vector, mbs []Minibatch) {
 	nMiniBatches := len(mbs)
 	for layer := range n.nodes {
 		if layer == 0 {
 			continue
 		}
 
-		i := n.nodes[layer]
-		j := n.nodes[layer-1]
-		dCdw := LinAlg.MakeEmptyMatrix(i, j)
-		dCdb := LinAlg.MakeEmptyVector(i)
+		dCdw := &dw[layer]
+		dCdb := &db[layer]
+		dCdw.Scalar(0)
+		dCdb.Scalar(0)
 		for mbIdx := range mbs {
-			mb := mbs[mbIdx]
-			delta := mb.delta[layer]
-			dCdb.Add(&delta)
-			tmp := LinAlg.OuterProduct(&delta, &mb.a[layer-1])
-			dCdw.Add(tmp)
+			mb := &mbs[mbIdx]
+			delta := &mb.delta[layer]
+			dCdb.Add(delta)
+			LinAlg.AddOuterProductTo(dCdw, 1, delta, &mb.a[layer-1])
 		}
-		dw[layer] = *dCdw.Scalar(1 / float64(nMiniBatches))
-		db[layer] = *dCdb.Scalar(1 / float64(nMiniBatches))
+		dCdw.Scalar(1 / float64(nMiniBatches))
+		dCdb.Scalar(1 / float64(nMiniBatches))
 	}
-	return dw, db
 }
 
 func (n *Network) UpdateNetwork(eta float32, lambda float64, dw []LinAlg.Matrix, db []LinAlg.Vector, nTrainingSamples int) {
@@ -258,10 +424,34 @@ func (n *Network) UpdateNetwork(eta float32, lambda float64, dw []LinAlg.Matrix,
 }
 
 func (n *Network) Train(trainingSamples []MNISTImport.TrainingSample, validationSamples []MNISTImport.TrainingSample, epochs int, eta float32, lambda float64, miniBatchSize int, costFunction CostFunction) {
+	n.TrainSource(MNISTImport.TrainingSamples(trainingSamples), validationSamples, epochs, eta, lambda, miniBatchSize, costFunction)
+}
+
+// Like Train, but the training samples are only materialized per minibatch.
+// The cost is reported only for in-memory training samples.
+func (n *Network) TrainSource(trainingSamples MNISTImport.SampleSource, validationSamples []MNISTImport.TrainingSample, epochs int, eta float32, lambda float64, miniBatchSize int, costFunction CostFunction) {
+	if n.augmenter != nil && n.preprocessor != nil && n.preprocessor.Method != Preprocessing.MinMax {
+		panic(fmt.Sprintf("Network.TrainSource: Augmentation cannot be combined with %s preprocessing", n.preprocessor.Method))
+	}
+
 	// Stochastic Gradient Decent
-	sizeMiniBatch := min(len(trainingSamples), miniBatchSize)
-	nMiniBatches := len(trainingSamples) / sizeMiniBatch
+	nTrainingSamples := trainingSamples.Length()
+	sizeMiniBatch := min(nTrainingSamples, miniBatchSize)
+	nMiniBatches := nTrainingSamples / sizeMiniBatch
 	mbs := CreateMiniBatches(sizeMiniBatch, n.GetLayers())
+	dw, db := n.createDerivatives()
+
+	// training samples of the current minibatch
+	samples := make([]MNISTImport.TrainingSample, sizeMiniBatch)
+
+	// augmented input activations, one per minibatch slot
+	var augmented []LinAlg.Vector
+	if n.augmenter != nil {
+		augmented = make([]LinAlg.Vector, sizeMiniBatch)
+		for idx := range augmented {
+			augmented[idx] = *LinAlg.MakeEmptyVector(n.nodes[0])
+		}
+	}
 
 	configuration := ""
 	for i := 0; i < len(n.nodes)-1; i++ {
@@ -269,63 +459,79 @@ func (n *Network) Train(trainingSamples []MNISTImport.TrainingSample, validation
 	}
 	configuration += fmt.Sprintf("%d\n", n.nodes[len(n.nodes)-1])
 	fmt.Print("\nNetwork configuration: ", configuration)
-	fmt.Printf("Training batch size: %d\n", len(trainingSamples))
+	fmt.Printf("Training batch size: %d\n", nTrainingSamples)
 	fmt.Printf("Validation batch size: %d\n", len(validationSamples))
 	fmt.Printf("Minibatch size: %d\n", sizeMiniBatch)
 	fmt.Printf("Number of minibatches: %d\n", nMiniBatches)
 	fmt.Printf("Learning rate: %f\n", eta)
 	fmt.Printf("Cost function: %s\n", costFunction)
+	if n.augmenter != nil {
+		fmt.Printf("Augmentation: %s\n", n.augmenter)
+	}
 	fmt.Printf("L2 regularization: %f\n\n", lambda)
 
 	var innerLoop = func(maxIndex int, offset int, indices []int) {
 		for i := 0; i < maxIndex; i++ {
-			mb := mbs[i]
+			mb := &mbs[i]
 			index := indices[offset*sizeMiniBatch+i]
-			x := trainingSamples[index]
+			x := &samples[i]
+			trainingSamples.Sample(index, x)
 			mb.a[0] = x.InputActivations
-			n.Feedforward(&mb)
-			costFunction.CalculateErrorInOutputLayer(n, &x.OutputActivations, &mb)
-			n.BackpropagateError(&mb)
+			if n.augmenter != nil {
+				n.augmenter.Apply(&augmented[i], &x.InputActivations)
+				mb.a[0] = augmented[i]
+			}
+			n.Feedforward(mb)
+			costFunction.CalculateErrorInOutputLayer(n, &x.OutputActivations, mb)
+			n.BackpropagateError(mb)
 		}
-		dw, db := n.CalculateDerivatives(mbs)
-		n.UpdateNetwork(eta, lambda, dw, db, len(trainingSamples))
+		n.calculateDerivativesTo(dw, db, mbs)
+		n.UpdateNetwork(eta, lambda, dw, db, nTrainingSamples)
 	}
 
 	for epoch := 0; epoch < epochs; epoch++ {
-		indices := GenerateRandomIndices(len(trainingSamples))
+		indices := GenerateRandomIndices(nTrainingSamples)
 		for j := 0; j < nMiniBatches; j++ {
 			innerLoop(sizeMiniBatch, j, indices)
 		}
-		if remainder := len(trainingSamples) - sizeMiniBatch*nMiniBatches; remainder > 0 {
+		if remainder := nTrainingSamples - sizeMiniBatch*nMiniBatches; remainder > 0 {
 			innerLoop(remainder, nMiniBatches, indices)
 		}
 		output := fmt.Sprintf("Epoch %d", epoch+1)
-		accuracy := n.RunSamples(trainingSamples, false)
+		accuracy := n.RunSource(trainingSamples, false)
 		output += fmt.Sprintf(" - training accuracy %f", accuracy)
 		if len(validationSamples) > 0 {
 			accuracy := n.RunSamples(validationSamples, false)
 			output += fmt.Sprintf(" - validation accuracy %f", accuracy)
 		}
-		cost := costFunction.Evaluate(n, lambda, trainingSamples)
-		output += fmt.Sprintf(" - cost %f\n", cost)
-		fmt.Print(output)
+		if ts, ok := trainingSamples.(MNISTImport.TrainingSamples); ok {
+			cost := costFunction.Evaluate(n, lambda, ts)
+			output += fmt.Sprintf(" - cost %f", cost)
+		}
+		fmt.Print(output + "\n")
 	}
 }
 
 func (n *Network) RunSamples(trainingSamples []MNISTImport.TrainingSample, showFailures bool) float32 {
+	return n.RunSource(MNISTImport.TrainingSamples(trainingSamples), showFailures)
+}
+
+func (n *Network) RunSource(trainingSamples MNISTImport.SampleSource, showFailures bool) float32 {
 	var correctPredictions int
+	var x MNISTImport.TrainingSample
 	mb := CreateMiniBatch(n.nodes)
-	for testIdx := range trainingSamples {
-		mb.a[0] = trainingSamples[testIdx].InputActivations
+	for testIdx := 0; testIdx < trainingSamples.Length(); testIdx++ {
+		trainingSamples.Sample(testIdx, &x)
+		mb.a[0] = x.InputActivations
 		n.Feedforward(&mb)
 		predictionClass := GetClass(n.GetOutputLayerActivations(&mb))
-		expectedClass := GetClass(&trainingSamples[testIdx].OutputActivations)
+		expectedClass := GetClass(&x.OutputActivations)
 		if expectedClass == predictionClass {
 			correctPredictions++
 		} else if showFailures {
 			fmt.Printf("Image %d: is %d, classified as %d\n", testIdx, expectedClass, predictionClass)
 		}
 	}
-	accuracy := float32(correctPredictions) / float32(len(trainingSamples))
+	accuracy := float32(correctPredictions) / float32(trainingSamples.Length())
 	return accuracy
 }
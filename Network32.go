@@ -0,0 +1,214 @@
+package main
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"SimpleNeuralNet/MNISTImport"
+	"SimpleNeuralNet/Preprocessing"
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math"
+)
+
+// Single precision copy of a trained Network for inference. Halves the
+// memory and bandwidth of the weights compared to Network.
+type Network32 struct {
+	nodes   []int
+	biases  []LinAlg.Vector32
+	weights []LinAlg.Matrix32
+
+	// applied in double precision to raw input activations by Predict
+	preprocessor *Preprocessing.Preprocessor
+}
+
+//
+// Implement interface 'GobEncoder'
+//
+func (n *Network32) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	err := encoder.Encode(n.nodes)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(n.biases)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(n.weights)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(n.preprocessor != nil)
+	if err != nil {
+		return nil, err
+	}
+	if n.preprocessor != nil {
+		err = encoder.Encode(n.preprocessor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return w.Bytes(), nil
+}
+
+//
+// Implement interface 'GobDecoder'
+//
+func (n *Network32) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	err := decoder.Decode(&n.nodes)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&n.biases)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&n.weights)
+	if err != nil {
+		return err
+	}
+	err = n.validate("Network32.GobDecode")
+	if err != nil {
+		return err
+	}
+	n.preprocessor, err = decodePreprocessor("Network32.GobDecode", decoder, n.nodes)
+	return err
+}
+
+// Checks that the decoded weights and biases match the layer sizes
+func (n *Network32) validate(op string) error {
+	biasSizes := make([]int, len(n.biases))
+	for layer := range n.biases {
+		biasSizes[layer] = n.biases[layer].Size()
+	}
+	weightShapes := make([][2]int, len(n.weights))
+	for layer := range n.weights {
+		weightShapes[layer] = [2]int{n.weights[layer].Rows, n.weights[layer].Cols}
+	}
+	return validateLayers(op, n.nodes, biasSizes, weightShapes)
+}
+
+// Converts the weights and biases to single precision
+func (n *Network) ToFloat32() Network32 {
+	result := Network32{nodes: n.nodes, preprocessor: n.preprocessor}
+	result.biases = make([]LinAlg.Vector32, len(n.nodes))
+	result.weights = make([]LinAlg.Matrix32, len(n.nodes))
+	for layer := range n.nodes {
+		if layer == 0 {
+			continue
+		}
+		result.biases[layer] = *n.GetBias(layer).ToFloat32()
+		result.weights[layer] = *n.GetWeights(layer).ToFloat32()
+	}
+	return result
+}
+
+// Converts the weights and biases back to double precision, i.e. to continue
+// training
+func (n *Network32) ToFloat64() Network {
+	result := Network{nodes: n.nodes, preprocessor: n.preprocessor}
+	result.biases = make([]LinAlg.Vector, len(n.nodes))
+	result.weights = make([]LinAlg.Matrix, len(n.nodes))
+	for layer := range n.nodes {
+		if layer == 0 {
+			continue
+		}
+		result.biases[layer] = *n.biases[layer].ToFloat64()
+		result.weights[layer] = *n.weights[layer].ToFloat64()
+	}
+	return result
+}
+
+func (n *Network32) GetLayers() []int {
+	return n.nodes
+}
+
+func (n *Network32) GetBias(layer int) *LinAlg.Vector32 {
+	return &n.biases[layer]
+}
+
+func (n *Network32) GetWeights(layer int) *LinAlg.Matrix32 {
+	return &n.weights[layer]
+}
+
+func Sigmoid32(z float32) float32 {
+	return float32(1.0 / (1.0 + math.Exp(-float64(z))))
+}
+
+// Per-layer activations of a Network32, reused across Feedforward calls
+func (n *Network32) createActivations() []LinAlg.Vector32 {
+	a := make([]LinAlg.Vector32, len(n.nodes))
+	for layer, nNodes := range n.nodes {
+		a[layer] = *LinAlg.MakeEmptyVector32(nNodes)
+	}
+	return a
+}
+
+// Feeds a[0] forward, writing the activations of layer l into a[l]
+func (n *Network32) feedforward(a []LinAlg.Vector32) *LinAlg.Vector32 {
+	for layer := range n.nodes {
+		if layer == 0 {
+			continue
+		}
+		z := &a[layer]
+		LinAlg.MulVec32To(z, n.GetWeights(layer), &a[layer-1]).Add(n.GetBias(layer))
+		LinAlg.F32To(z, z, Sigmoid32)
+	}
+	return &a[len(a)-1]
+}
+
+// Returns the output layer activations for raw, not preprocessed input activations
+func (n *Network32) Predict(input *LinAlg.Vector) *LinAlg.Vector32 {
+	if n.preprocessor != nil {
+		input = n.preprocessor.Transform(input)
+	}
+	a := n.createActivations()
+	a[0] = *input.ToFloat32()
+	return n.feedforward(a)
+}
+
+// Returns the predicted class for raw input activations, and the output layer
+// activations normalized to probabilities
+func (n *Network32) Classify(input *LinAlg.Vector) (int, []float64) {
+	a := n.Predict(input).ToFloat64()
+	probabilities := make([]float64, a.Size())
+	sum := a.Sum()
+	for idx := range probabilities {
+		probabilities[idx] = a.Get(idx) / sum
+	}
+	return GetClass(a), probabilities
+}
+
+func (n *Network32) RunSamples(trainingSamples []MNISTImport.TrainingSample, showFailures bool) float32 {
+	return n.RunSource(MNISTImport.TrainingSamples(trainingSamples), showFailures)
+}
+
+func (n *Network32) RunSource(trainingSamples MNISTImport.SampleSource, showFailures bool) float32 {
+	var correctPredictions int
+	var x MNISTImport.TrainingSample
+	a := n.createActivations()
+	output := LinAlg.MakeEmptyVector(n.nodes[len(n.nodes)-1])
+	for testIdx := 0; testIdx < trainingSamples.Length(); testIdx++ {
+		trainingSamples.Sample(testIdx, &x)
+		input := &a[0]
+		for idx := 0; idx < input.Size(); idx++ {
+			input.Set(idx, float32(x.InputActivations.Get(idx)))
+		}
+		o := n.feedforward(a)
+		for idx := 0; idx < o.Size(); idx++ {
+			output.Set(idx, float64(o.Get(idx)))
+		}
+		predictionClass := GetClass(output)
+		expectedClass := GetClass(&x.OutputActivations)
+		if expectedClass == predictionClass {
+			correctPredictions++
+		} else if showFailures {
+			fmt.Printf("Image %d: is %d, classified as %d\n", testIdx, expectedClass, predictionClass)
+		}
+	}
+	accuracy := float32(correctPredictions) / float32(trainingSamples.Length())
+	return accuracy
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"SimpleNeuralNet/LinAlg"
+	"fmt"
+	"math"
 	"math/rand"
 )
 
@@ -26,18 +28,18 @@ func GenerateRandomIndices(size int) []int {
 }
 
 func GetError(outputActivations LinAlg.Vector, a *LinAlg.Vector) float64 {
-	e := LinAlg.SubtractVectors(&outputActivations, a)
-	return e.EuklideanNorm()
+	// Euclidean norm of the difference, without allocating it
+	if outputActivations.Size() != a.Size() {
+		panic(fmt.Sprintf("GetError: Vector sizes %d and %d must be the same", outputActivations.Size(), a.Size()))
+	}
+	var e float64
+	for idx := 0; idx < a.Size(); idx++ {
+		d := outputActivations.Get(idx) - a.Get(idx)
+		e += d * d
+	}
+	return math.Sqrt(e)
 }
 
 func GetClass(a *LinAlg.Vector) int {
-	var index int
-	var value float64 = -1
-	for idx := 0; idx < a.Size(); idx++ {
-		if a.Get(idx) > value {
-			value = a.Get(idx)
-			index = idx
-		}
-	}
-	return index
+	return a.ArgMax()
 }
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// The .npz bundle holds one array per layer l >= 1, "weights_<l>" of shape
+// (nodes[l], nodes[l-1]) and "biases_<l>" of shape (nodes[l],), so that in
+// NumPy a^l = sigmoid(weights_l @ a^{l-1} + biases_l).
+
+func npzWeightsName(layer int) string {
+	return fmt.Sprintf("weights_%d.npy", layer)
+}
+
+func npzBiasesName(layer int) string {
+	return fmt.Sprintf("biases_%d.npy", layer)
+}
+
+// Writes the weights and biases as a NumPy .npz archive. The preprocessor
+// is not exported.
+func (n *Network) ExportNpz(w io.Writer) error {
+	archive := zip.NewWriter(w)
+	for layer := 1; layer < len(n.nodes); layer++ {
+		f, err := archive.Create(npzWeightsName(layer))
+		if err != nil {
+			return err
+		}
+		if err = n.GetWeights(layer).WriteNpy(f); err != nil {
+			return err
+		}
+		f, err = archive.Create(npzBiasesName(layer))
+		if err != nil {
+			return err
+		}
+		if err = n.GetBias(layer).WriteNpy(f); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func (n *Network) ExportNpzFile(fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	err = n.ExportNpz(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Creates a network from the weights and biases of a NumPy .npz archive as
+// written by ExportNpz. The layer sizes follow from the weight shapes.
+func ImportNpz(r io.ReaderAt, size int64) (*Network, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	var weights []*LinAlg.Matrix
+	var biases []*LinAlg.Vector
+	for layer := 1; files[npzWeightsName(layer)] != nil; layer++ {
+		var w *LinAlg.Matrix
+		err = readNpzEntry(files[npzWeightsName(layer)], func(r io.Reader) (err error) {
+			w, err = LinAlg.ReadNpyMatrix(r)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		bFile := files[npzBiasesName(layer)]
+		if bFile == nil {
+			return nil, fmt.Errorf("ImportNpz: %s missing", npzBiasesName(layer))
+		}
+		var b *LinAlg.Vector
+		err = readNpzEntry(bFile, func(r io.Reader) (err error) {
+			b, err = LinAlg.ReadNpyVector(r)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+		weights = append(weights, w)
+		biases = append(biases, b)
+	}
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("ImportNpz: %s missing", npzWeightsName(1))
+	}
+
+	layers := []int{weights[0].Cols}
+	for _, w := range weights {
+		layers = append(layers, w.Rows)
+	}
+	network := CreateNetwork(layers)
+	for idx := range weights {
+		network.SetWeights(idx+1, weights[idx])
+		network.SetBias(idx+1, biases[idx])
+	}
+	if err = network.validate("ImportNpz"); err != nil {
+		return nil, err
+	}
+	return &network, nil
+}
+
+func ImportNpzFile(fileName string) (*Network, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return ImportNpz(bytes.NewReader(data), int64(len(data)))
+}
+
+func readNpzEntry(f *zip.File, read func(io.Reader) error) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err = read(rc); err != nil {
+		return fmt.Errorf("ImportNpz: %s: %v", f.Name, err)
+	}
+	return nil
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"SimpleNeuralNet/Augmentation"
 	"SimpleNeuralNet/LinAlg"
 	"SimpleNeuralNet/MNISTImport"
+	"SimpleNeuralNet/Preprocessing"
 	"SimpleNeuralNet/Utility"
 	"bytes"
 	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -195,6 +198,44 @@ func TestFeedforward(t *testing.T) {
 	}
 }
 
+func TestFeedforwardSparse(t *testing.T) {
+	network, expected := CreateTestNetwork()
+	expected.a[0].Set(0, 0)
+	network.Feedforward(&expected)
+	mb := CreateMiniBatch(network.GetLayers())
+
+	network.FeedforwardSparse(LinAlg.MakeSparseVector(2, []int{1}, []float64{2}), &mb)
+
+	// Assert
+	for layer := range network.GetLayers() {
+		for idx := 0; idx < expected.a[layer].Size(); idx++ {
+			if floatEquals(mb.a[layer].Get(idx), expected.a[layer].Get(idx), EPSILON) == false {
+				t.Errorf("Layer %d, activation %d: expected %v, but is %v", layer, idx, expected.a[layer].Get(idx), mb.a[layer].Get(idx))
+			}
+		}
+	}
+}
+
+func TestFeedforwardSparseKeepsPreviousSample(t *testing.T) {
+	// Arrange
+	network, _ := CreateTestNetwork()
+	mb := CreateMiniBatch(network.GetLayers())
+	sample := LinAlg.MakeVector([]float64{0.5, 0.25})
+	mb.a[0] = *sample
+	network.Feedforward(&mb)
+
+	// Act
+	network.FeedforwardSparse(LinAlg.MakeSparseVector(2, []int{1}, []float64{2}), &mb)
+
+	// Assert
+	if sample.Get(0) != 0.5 || sample.Get(1) != 0.25 {
+		t.Errorf("Sparse feedforward must not overwrite the previous sample, but it is %v", sample)
+	}
+	if mb.a[0].Get(0) != 0 || mb.a[0].Get(1) != 2 {
+		t.Errorf("Input activations must be the dense sparse input, but are %v", &mb.a[0])
+	}
+}
+
 func TestCalculateErrorInOutputLayer(t *testing.T) {
 	network := CreateTestNetwork2()
 	mb := CreateMiniBatch([]int{2, 3, 2})
@@ -267,6 +308,32 @@ func TestCalculateDerivatives(t *testing.T) {
 	}
 }
 
+func TestTrainingStepDoesNotAllocate(t *testing.T) {
+	network := CreateNetwork([]int{28 * 28, 30, 10})
+	network.InitializeNetworkWeightsAndBiases()
+	mbs := CreateMiniBatches(2, network.GetLayers())
+	dw, db := network.createDerivatives()
+	x := MNISTImport.CreateTrainingSample(LinAlg.MakeEmptyVector(28*28), LinAlg.MakeEmptyVector(10))
+	var costFunction CostFunction = CrossEntropyCostFunction{}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		for idx := range mbs {
+			mb := &mbs[idx]
+			mb.a[0] = x.InputActivations
+			network.Feedforward(mb)
+			costFunction.CalculateErrorInOutputLayer(&network, &x.OutputActivations, mb)
+			network.BackpropagateError(mb)
+		}
+		network.calculateDerivativesTo(dw, db, mbs)
+		network.UpdateNetwork(0.1, 0, dw, db, 100)
+	})
+
+	// Assert
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, but got %f", allocs)
+	}
+}
+
 func TestTrain(t *testing.T) {
 	network, _ := CreateTestNetwork()
 
@@ -329,6 +396,293 @@ func TestSingleNeuronCrossEntropyCostTrain(t *testing.T) {
 	}
 }
 
+func TestTrainWithAugmentation(t *testing.T) {
+	// Arrange
+	layers := []int{28 * 28, 30, 10}
+	network := CreateNetwork(layers)
+	network.InitializeNetworkWeightsAndBiasesFrom(rand.New(rand.NewSource(1)))
+	plain := CreateNetwork(layers)
+	plain.InitializeNetworkWeightsAndBiasesFrom(rand.New(rand.NewSource(1)))
+	config := Augmentation.MNISTConfig()
+	config.Seed = 1
+	augmenter := Augmentation.CreateAugmenter(config)
+	network.SetAugmenter(augmenter)
+
+	trainingData := MNISTImport.ImportData("./test_data/", "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	ts := trainingData.GenerateTrainingSamples(trainingData.Length())
+	before := ts[0].InputActivations.Get(14*28 + 14)
+
+	// Act, a single minibatch, so that the shuffling does not matter
+	network.Train(ts, []MNISTImport.TrainingSample{}, 1, 0.5, 0, len(ts), QuadraticCostFunction{})
+	plain.Train(ts, []MNISTImport.TrainingSample{}, 1, 0.5, 0, len(ts), QuadraticCostFunction{})
+
+	// Assert
+	if after := ts[0].InputActivations.Get(14*28 + 14); after != before {
+		t.Error("Augmentation must not modify the training samples")
+	}
+	// the augmenter drew random numbers, so it continues differently than a fresh one
+	next := LinAlg.MakeEmptyVector(28 * 28)
+	fresh := LinAlg.MakeEmptyVector(28 * 28)
+	augmenter.Apply(next, &ts[0].InputActivations)
+	Augmentation.CreateAugmenter(config).Apply(fresh, &ts[0].InputActivations)
+	if ok, _ := fresh.EqualApprox(next, 0, 0); ok {
+		t.Error("Training must call the augmenter")
+	}
+	// training saw perturbed inputs, so it ends up with other weights than
+	// training on the stored samples
+	if ok, _ := plain.GetWeights(1).EqualApprox(network.GetWeights(1), 1e-9, 0); ok {
+		t.Error("Training must use the augmented input activations")
+	}
+}
+
+func TestTrainRejectsAugmentationWithPreprocessor(t *testing.T) {
+	// Arrange
+	network := CreateNetwork([]int{28 * 28, 30, 10})
+	network.InitializeNetworkWeightsAndBiasesFrom(rand.New(rand.NewSource(1)))
+	network.SetAugmenter(Augmentation.CreateAugmenter(Augmentation.MNISTConfig()))
+	trainingData := MNISTImport.ImportData("./test_data/", "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	ts := trainingData.GenerateTrainingSamples(trainingData.Length())
+	preprocessor := Preprocessing.CreatePreprocessor(Preprocessing.ZScore)
+	preprocessor.Fit(ts)
+	network.SetPreprocessor(preprocessor)
+	ts = preprocessor.TransformSamples(ts)
+
+	// Act
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		network.Train(ts, []MNISTImport.TrainingSample{}, 1, 0.5, 0, len(ts), QuadraticCostFunction{})
+	}()
+
+	// Assert
+	if recovered == nil {
+		t.Error("Training with augmentation must reject Z-Score preprocessed samples")
+	}
+}
+
+func TestPredictWithPreprocessor(t *testing.T) {
+	network := CreateTestNetwork2()
+	samples := []MNISTImport.TrainingSample{
+		MNISTImport.CreateTrainingSample(LinAlg.MakeVector([]float64{10, 100}), LinAlg.MakeVector([]float64{1, 0})),
+		MNISTImport.CreateTrainingSample(LinAlg.MakeVector([]float64{20, 300}), LinAlg.MakeVector([]float64{0, 1})),
+	}
+	p := Preprocessing.CreatePreprocessor(Preprocessing.MinMax)
+	p.Fit(samples)
+	network.SetPreprocessor(p)
+
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, &network)
+	if err != nil {
+		t.Fatal("Error serializing network")
+	}
+	readNetwork := new(Network)
+	err = Utility.ReadGob(&buf, readNetwork)
+	if err != nil {
+		t.Fatal("Error deserializing network")
+	}
+
+	// Act
+	a := readNetwork.Predict(&samples[1].InputActivations)
+
+	// Assert
+	mb := CreateMiniBatch(network.GetLayers())
+	mb.a[0] = *LinAlg.MakeVector([]float64{1, 1})
+	network.Feedforward(&mb)
+	expected := network.GetOutputLayerActivations(&mb)
+	for idx := 0; idx < expected.Size(); idx++ {
+		if floatEquals(a.Get(idx), expected.Get(idx), EPSILON) == false {
+			t.Errorf("Predict must preprocess the input, %f != %f", expected.Get(idx), a.Get(idx))
+		}
+	}
+}
+
+func TestTrainSource(t *testing.T) {
+	network := CreateNetwork([]int{28 * 28, 30, 10})
+	network.InitializeNetworkWeightsAndBiases()
+	source, err := MNISTImport.OpenData("./test_data/", "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer source.Close()
+
+	network.TrainSource(source, []MNISTImport.TrainingSample{}, 3, 3, 0, 10, CrossEntropyCostFunction{})
+
+	// Assert
+	data := MNISTImport.ImportData("./test_data/", "train-images50.idx3-ubyte", "train-labels50.idx1-ubyte")
+	ts := data.GenerateTrainingSamples(data.Length())
+	if expected, accuracy := network.RunSamples(ts, false), network.RunSource(source, false); expected != accuracy {
+		t.Errorf("Accuracy on lazily loaded samples must be %f, but is %f", expected, accuracy)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	network := CreateTestNetwork2()
+
+	class, probabilities := network.Classify(LinAlg.MakeVector([]float64{0.5, 1}))
+
+	// Assert
+	var sum float64
+	for _, p := range probabilities {
+		sum += p
+	}
+	if floatEquals(sum, 1, EPSILON) == false {
+		t.Errorf("Probabilities must sum to 1, but sum to %f", sum)
+	}
+	if probabilities[class] < probabilities[1-class] {
+		t.Errorf("Class %d must have the largest probability", class)
+	}
+}
+
+func TestNetwork32(t *testing.T) {
+	// Arrange
+	network := CreateTestNetwork2()
+	input := LinAlg.MakeVector([]float64{0.5, 1})
+
+	// Act
+	network32 := network.ToFloat32()
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, &network32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(Network32)
+	err = Utility.ReadGob(&buf, decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	expected := network.Predict(input)
+	a := decoded.Predict(input)
+	for idx := 0; idx < expected.Size(); idx++ {
+		if floatEquals(float64(a.Get(idx)), expected.Get(idx), 0.00001) == false {
+			t.Errorf("Output activation %d: %f != %f", idx, expected.Get(idx), a.Get(idx))
+		}
+	}
+	class, _ := decoded.Classify(input)
+	if expectedClass, _ := network.Classify(input); class != expectedClass {
+		t.Errorf("Single precision network classifies as %d instead of %d", class, expectedClass)
+	}
+	network64 := decoded.ToFloat64()
+	if w := network64.GetWeights(1); floatEquals(w.Get(0, 0), network.GetWeights(1).Get(0, 0), 0.00001) == false {
+		t.Errorf("Round trip error, %f != %f", network.GetWeights(1).Get(0, 0), w.Get(0, 0))
+	}
+}
+
+func TestGobDecodeRejectsInconsistentNetwork(t *testing.T) {
+	network := CreateTestNetwork2()
+	network.SetWeights(1, LinAlg.MakeEmptyMatrix(2, 2))
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, &network)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Utility.ReadGob(&buf, new(Network))
+
+	// Assert
+	if _, ok := err.(*LinAlg.DimensionError); ok == false {
+		t.Errorf("Expected *LinAlg.DimensionError, but got %v", err)
+	}
+}
+
+func TestGobDecodeRejectsOverflowingLayers(t *testing.T) {
+	// 4 * 2^62 elements overflow to 0, matching the empty weights
+	network := Network{
+		nodes:   []int{1 << 62, 4},
+		biases:  []LinAlg.Vector{{}, *LinAlg.MakeEmptyVector(4)},
+		weights: []LinAlg.Matrix{{}, {Rows: 4, Cols: 1 << 62}},
+	}
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, &network)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Utility.ReadGob(&buf, new(Network))
+	layerErr := validateLayers("Network.GobDecode", network.nodes, []int{0, 4}, [][2]int{{0, 0}, {4, 1 << 62}})
+
+	// Assert
+	if _, ok := err.(*LinAlg.DimensionError); ok == false {
+		t.Errorf("Expected *LinAlg.DimensionError, but got %v", err)
+	}
+	if _, ok := layerErr.(*LinAlg.DimensionError); ok == false {
+		t.Errorf("Expected *LinAlg.DimensionError for the layer sizes, but got %v", layerErr)
+	}
+}
+
+func TestGobDecodeRejectsMismatchedPreprocessor(t *testing.T) {
+	network := CreateTestNetwork2()
+	preprocessor := Preprocessing.CreatePreprocessor(Preprocessing.ZScore)
+	input := LinAlg.MakeVector([]float64{1, 2, 3})
+	preprocessor.Fit([]MNISTImport.TrainingSample{MNISTImport.CreateTrainingSample(input, LinAlg.MakeEmptyVector(2))})
+	network.SetPreprocessor(preprocessor)
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, &network)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Utility.ReadGob(&buf, new(Network))
+
+	// Assert
+	if _, ok := err.(*LinAlg.DimensionError); ok == false {
+		t.Errorf("Expected *LinAlg.DimensionError, but got %v", err)
+	}
+}
+
+func TestNpzRoundTrip(t *testing.T) {
+	network := CreateTestNetwork2()
+	var buf bytes.Buffer
+	err := network.ExportNpz(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := ImportNpz(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Assert
+	if layers := imported.GetLayers(); len(layers) != 3 || layers[0] != 2 || layers[1] != 3 || layers[2] != 2 {
+		t.Fatalf("Expected layers [2 3 2], but got %v", layers)
+	}
+	for layer := 1; layer < 3; layer++ {
+		if ok, diff := imported.GetWeights(layer).EqualApprox(network.GetWeights(layer), 0, 0); ok == false {
+			t.Errorf("Weights of layer %d differ: %s", layer, diff)
+		}
+		if ok, diff := imported.GetBias(layer).EqualApprox(network.GetBias(layer), 0, 0); ok == false {
+			t.Errorf("Biases of layer %d differ: %s", layer, diff)
+		}
+	}
+}
+
+func TestInitializeNetworkWeightsAndBiasesFrom(t *testing.T) {
+	n1 := CreateNetwork([]int{4, 3, 2})
+	n2 := CreateNetwork([]int{4, 3, 2})
+
+	n1.InitializeNetworkWeightsAndBiasesFrom(rand.New(rand.NewSource(5)))
+	n2.InitializeNetworkWeightsAndBiasesFrom(rand.New(rand.NewSource(5)))
+
+	// Assert
+	for layer := 1; layer < 3; layer++ {
+		w := n1.GetWeights(layer)
+		if w.Rows != n1.GetLayers()[layer] || w.Cols != n1.GetLayers()[layer-1] {
+			t.Errorf("Weights of layer %d have wrong size %dx%d", layer, w.Rows, w.Cols)
+		}
+		if w.Min() < 0 || w.Max() >= 0.01 {
+			t.Errorf("Weights of layer %d must be in [0, 0.01)", layer)
+		}
+		if ok, diff := w.EqualApprox(n2.GetWeights(layer), 0, 0); ok == false {
+			t.Errorf("Same seed must give the same weights: %s", diff)
+		}
+		if ok, diff := n1.GetBias(layer).EqualApprox(n2.GetBias(layer), 0, 0); ok == false {
+			t.Errorf("Same seed must give the same biases: %s", diff)
+		}
+	}
+}
+
 func TestTrainWithMNIST(t *testing.T) {
 	network := CreateNetwork([]int{28 * 28, 100, 10})
 	network.InitializeNetworkWeightsAndBiases()
@@ -377,3 +731,27 @@ func TestSerialization(t *testing.T) {
 		t.Error("Networks not equal")
 	}
 }
+
+func TestFeedforwardBatch(t *testing.T) {
+	// Arrange
+	network, _ := CreateTestNetwork()
+	samples := [][]float64{{0, 2}, {1, -1}, {0.5, 0.25}}
+	inputs := LinAlg.MakeEmptyMatrix(2, len(samples))
+	for col, sample := range samples {
+		inputs.SetCol(col, LinAlg.MakeVector(sample))
+	}
+
+	// Act
+	outputs := network.FeedforwardBatch(inputs)
+
+	// Assert
+	for col, sample := range samples {
+		mb := CreateMiniBatch(network.GetLayers())
+		mb.a[0] = *LinAlg.MakeVector(sample)
+		network.Feedforward(&mb)
+		expected := network.GetOutputLayerActivations(&mb)
+		if ok, diff := expected.EqualApprox(outputs.Col(col), EPSILON, 0); ok == false {
+			t.Errorf("Sample %d: %s", col, diff)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package Preprocessing
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"SimpleNeuralNet/MNISTImport"
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math"
+)
+
+type Method int
+
+const (
+	// Scale each feature to [0, 1]
+	MinMax Method = iota
+
+	// Subtract the mean of each feature and divide by its standard deviation
+	ZScore
+
+	// Subtract the mean image
+	MeanSubtraction
+
+	// Decorrelate the features and scale them to unit variance, in the
+	// basis of the principal components
+	PCAWhitening
+
+	// Like PCA whitening, but rotated back into the original basis, so the
+	// whitened images still look like images
+	ZCAWhitening
+)
+
+func (m Method) String() string {
+	switch m {
+	case MinMax:
+		return "Min-Max"
+	case ZScore:
+		return "Z-Score"
+	case MeanSubtraction:
+		return "Mean Subtraction"
+	case PCAWhitening:
+		return "PCA Whitening"
+	case ZCAWhitening:
+		return "ZCA Whitening"
+	}
+	return fmt.Sprintf("Method(%d)", int(m))
+}
+
+// Transforms input activations x into W ((x - offset) o scale). The
+// statistics are fitted on the training samples and serialized with the
+// network, so that raw inputs are preprocessed identically at inference.
+type Preprocessor struct {
+	Method Method
+
+	// Added to the eigenvalues of the covariance matrix when whitening, to
+	// avoid amplifying noise in directions of tiny variance
+	Epsilon float64
+
+	offset LinAlg.Vector
+	scale  LinAlg.Vector
+
+	// whitening matrix, empty unless whitening
+	whitening LinAlg.Matrix
+}
+
+func CreatePreprocessor(method Method) *Preprocessor {
+	return &Preprocessor{Method: method, Epsilon: 0.1}
+}
+
+//
+// Implement interface 'GobEncoder'
+//
+func (p *Preprocessor) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	err := encoder.Encode(p.Method)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(p.Epsilon)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(&p.offset)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(&p.scale)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(&p.whitening)
+	if err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+//
+// Implement interface 'GobDecoder'
+//
+func (p *Preprocessor) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	err := decoder.Decode(&p.Method)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&p.Epsilon)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&p.offset)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&p.scale)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&p.whitening)
+	if err != nil {
+		return err
+	}
+	return p.validate("Preprocessing.Preprocessor.GobDecode")
+}
+
+// Checks that the decoded statistics are consistent with each other
+func (p *Preprocessor) validate(op string) error {
+	if p.Method < MinMax || p.Method > ZCAWhitening {
+		return fmt.Errorf("%s: Unknown method %d", op, int(p.Method))
+	}
+	size := p.offset.Size()
+	if p.scale.Size() != size {
+		return &LinAlg.DimensionError{Op: op, Message: fmt.Sprintf("Offset has size %d, but scale has size %d", size, p.scale.Size())}
+	}
+	whitening := p.Method == PCAWhitening || p.Method == ZCAWhitening
+	if whitening && (p.whitening.Rows != size || p.whitening.Cols != size) {
+		return &LinAlg.DimensionError{Op: op, Message: fmt.Sprintf("Whitening matrix is %dx%d, but %dx%d expected", p.whitening.Rows, p.whitening.Cols, size, size)}
+	}
+	if whitening == false && p.whitening.Rows*p.whitening.Cols != 0 {
+		return &LinAlg.DimensionError{Op: op, Message: fmt.Sprintf("%s must not have a whitening matrix, but it is %dx%d", p.Method, p.whitening.Rows, p.whitening.Cols)}
+	}
+	return nil
+}
+
+// Size of the input activations the preprocessor was fitted to
+func (p *Preprocessor) Size() int {
+	return p.offset.Size()
+}
+
+func (p *Preprocessor) Fit(samples []MNISTImport.TrainingSample) {
+	if len(samples) == 0 {
+		panic("Preprocessing.Preprocessor.Fit: No samples")
+	}
+	size := samples[0].InputActivations.Size()
+	nSamples := float64(len(samples))
+	mean := LinAlg.MakeEmptyVector(size)
+	for idx := range samples {
+		mean.Add(&samples[idx].InputActivations)
+	}
+	mean.Scalar(1 / nSamples)
+
+	p.offset = *mean
+	p.scale = *LinAlg.MakeEmptyVector(size)
+	p.whitening = LinAlg.Matrix{}
+	for i := 0; i < size; i++ {
+		p.scale.Set(i, 1)
+	}
+
+	switch p.Method {
+	case MinMax:
+		for i := 0; i < size; i++ {
+			minValue := math.Inf(1)
+			maxValue := math.Inf(-1)
+			for idx := range samples {
+				value := samples[idx].InputActivations.Get(i)
+				minValue = math.Min(minValue, value)
+				maxValue = math.Max(maxValue, value)
+			}
+			p.offset.Set(i, minValue)
+			if maxValue > minValue {
+				p.scale.Set(i, 1/(maxValue-minValue))
+			}
+		}
+	case ZScore:
+		for i := 0; i < size; i++ {
+			var variance float64
+			for idx := range samples {
+				d := samples[idx].InputActivations.Get(i) - mean.Get(i)
+				variance += d * d
+			}
+			variance /= nSamples
+			if variance > 0 {
+				p.scale.Set(i, 1/math.Sqrt(variance))
+			}
+		}
+	case MeanSubtraction:
+	case PCAWhitening, ZCAWhitening:
+		p.whitening = *p.fitWhitening(samples, mean)
+	default:
+		panic(fmt.Sprintf("Preprocessing.Preprocessor.Fit: Unknown method %d", p.Method))
+	}
+}
+
+func (p *Preprocessor) fitWhitening(samples []MNISTImport.TrainingSample, mean *LinAlg.Vector) *LinAlg.Matrix {
+	size := mean.Size()
+	covariance := LinAlg.MakeEmptyMatrix(size, size)
+	d := make([]float64, size)
+	for idx := range samples {
+		x := &samples[idx].InputActivations
+		for i := range d {
+			d[i] = x.Get(i) - mean.Get(i)
+		}
+		for i := 0; i < size; i++ {
+			if d[i] == 0 {
+				continue
+			}
+			row := covariance.RowView(i)
+			for j := i; j < size; j++ {
+				row.Set(j, row.Get(j)+d[i]*d[j])
+			}
+		}
+	}
+	for i := 0; i < size; i++ {
+		for j := i; j < size; j++ {
+			c := covariance.Get(i, j) / float64(len(samples))
+			covariance.Set(i, j, c)
+			covariance.Set(j, i, c)
+		}
+	}
+
+	// C = U diag(lambda) U^T, and the PCA whitening matrix is
+	// diag(1 / sqrt(lambda + epsilon)) U^T. ZCA additionally rotates back by U.
+	// Directions without variance are scaled by 1 / sqrt(epsilon); only with
+	// epsilon = 0 they are zeroed, instead of dividing by zero.
+	eigenvalues, u := LinAlg.SymmetricEigen(covariance)
+	pca := LinAlg.MakeEmptyMatrix(size, size)
+	for k := 0; k < size; k++ {
+		lambda := math.Max(eigenvalues.Get(k), 0) + p.Epsilon
+		if lambda <= 1e-12 {
+			continue
+		}
+		pca.SetRow(k, u.Col(k).Scalar(1/math.Sqrt(lambda)))
+	}
+	if p.Method == PCAWhitening {
+		return pca
+	}
+	return u.Am(pca)
+}
+
+// Returns the preprocessed input activations
+func (p *Preprocessor) Transform(x *LinAlg.Vector) *LinAlg.Vector {
+	if x.Size() != p.offset.Size() {
+		panic(fmt.Sprintf("Preprocessing.Preprocessor.Transform: Vector size %d does not match fitted size %d", x.Size(), p.offset.Size()))
+	}
+	result := LinAlg.SubtractVectors(x, &p.offset).Hadamard(&p.scale)
+	if p.whitening.Rows > 0 {
+		result = p.whitening.Ax(result)
+	}
+	return result
+}
+
+// Returns copies of the samples with preprocessed input activations
+func (p *Preprocessor) TransformSamples(samples []MNISTImport.TrainingSample) []MNISTImport.TrainingSample {
+	result := make([]MNISTImport.TrainingSample, len(samples))
+	for idx := range samples {
+		result[idx] = MNISTImport.CreateTrainingSample(p.Transform(&samples[idx].InputActivations), &samples[idx].OutputActivations)
+	}
+	return result
+}
@@ -0,0 +1,190 @@
+package Preprocessing
+
+import (
+	"SimpleNeuralNet/LinAlg"
+	"SimpleNeuralNet/MNISTImport"
+	"SimpleNeuralNet/Utility"
+	"bytes"
+	"math"
+	"testing"
+)
+
+const (
+	EPSILON = 0.00000001
+)
+
+func floatEquals(a, b float64, eps float64) bool {
+	return math.Abs(a-b) < eps
+}
+
+func createSamples() []MNISTImport.TrainingSample {
+	inputs := [][]float64{{1, 10, 5, 0}, {2, 30, 3, 0}, {3, 20, 8, 0}, {6, 40, 1, 0}, {4, 15, 2, 0}}
+	samples := make([]MNISTImport.TrainingSample, len(inputs))
+	for idx := range inputs {
+		samples[idx] = MNISTImport.CreateTrainingSample(LinAlg.MakeVector(inputs[idx]), LinAlg.MakeEmptyVector(1))
+	}
+	return samples
+}
+
+func mean(samples []MNISTImport.TrainingSample, i int) float64 {
+	var sum float64
+	for idx := range samples {
+		sum += samples[idx].InputActivations.Get(i)
+	}
+	return sum / float64(len(samples))
+}
+
+func covariance(samples []MNISTImport.TrainingSample, i int, j int) float64 {
+	mi := mean(samples, i)
+	mj := mean(samples, j)
+	var sum float64
+	for idx := range samples {
+		x := &samples[idx].InputActivations
+		sum += (x.Get(i) - mi) * (x.Get(j) - mj)
+	}
+	return sum / float64(len(samples))
+}
+
+func TestMinMax(t *testing.T) {
+	// Arrange
+	samples := createSamples()
+	p := CreatePreprocessor(MinMax)
+
+	// Act
+	p.Fit(samples)
+	r := p.Transform(LinAlg.MakeVector([]float64{1, 40, 4.5, 0}))
+
+	// Assert
+	for idx, expected := range []float64{0, 1, 0.5, 0} {
+		if floatEquals(r.Get(idx), expected, EPSILON) == false {
+			t.Errorf("Feature %d must be %f, but is %f", idx, expected, r.Get(idx))
+		}
+	}
+}
+
+func TestZScore(t *testing.T) {
+	// Arrange
+	samples := createSamples()
+	p := CreatePreprocessor(ZScore)
+
+	// Act
+	p.Fit(samples)
+	transformed := p.TransformSamples(samples)
+
+	// Assert
+	for i := 0; i < 3; i++ {
+		if m := mean(transformed, i); floatEquals(m, 0, EPSILON) == false {
+			t.Errorf("Feature %d must have mean 0, but is %f", i, m)
+		}
+		if v := covariance(transformed, i, i); floatEquals(v, 1, EPSILON) == false {
+			t.Errorf("Feature %d must have variance 1, but is %f", i, v)
+		}
+	}
+	if v := transformed[0].InputActivations.Get(3); v != 0 {
+		t.Errorf("Constant feature must be 0, but is %f", v)
+	}
+}
+
+func TestMeanSubtraction(t *testing.T) {
+	// Arrange
+	samples := createSamples()
+	p := CreatePreprocessor(MeanSubtraction)
+
+	// Act
+	p.Fit(samples)
+	r := p.Transform(&samples[0].InputActivations)
+
+	// Assert
+	for idx, expected := range []float64{-2.2, -13, 1.2, 0} {
+		if floatEquals(r.Get(idx), expected, EPSILON) == false {
+			t.Errorf("Feature %d must be %f, but is %f", idx, expected, r.Get(idx))
+		}
+	}
+}
+
+func TestWhitening(t *testing.T) {
+	for _, method := range []Method{PCAWhitening, ZCAWhitening} {
+		// Arrange
+		samples := createSamples()
+		p := CreatePreprocessor(method)
+		p.Epsilon = 0
+
+		// Act
+		p.Fit(samples)
+		transformed := p.TransformSamples(samples)
+
+		// Assert
+		var trace float64
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				c := covariance(transformed, i, j)
+				if i == j {
+					trace += c
+					continue
+				}
+				if floatEquals(c, 0, 0.000001) == false {
+					t.Errorf("%s: Covariance (%d, %d) must be 0, but is %f", method, i, j, c)
+				}
+			}
+		}
+
+		// the constant feature has no variance, so only 3 directions remain
+		if floatEquals(trace, 3, 0.000001) == false {
+			t.Errorf("%s: Total variance must be 3, but is %f", method, trace)
+		}
+		if c := covariance(transformed, 3, 3); method == ZCAWhitening && floatEquals(c, 0, 0.000001) == false {
+			t.Errorf("%s: Constant feature must have variance 0, but is %f", method, c)
+		}
+	}
+}
+
+func TestPreprocessorSerialization(t *testing.T) {
+	// Arrange
+	p1 := CreatePreprocessor(ZCAWhitening)
+	p1.Fit(createSamples())
+	x := LinAlg.MakeVector([]float64{2, 25, 4, 0})
+
+	// Act
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, p1)
+	if err != nil {
+		t.Fatal("Error serializing preprocessor")
+	}
+	p2 := new(Preprocessor)
+	err = Utility.ReadGob(&buf, p2)
+	if err != nil {
+		t.Fatal("Error deserializing preprocessor")
+	}
+
+	// Assert
+	if p2.Method != p1.Method {
+		t.Errorf("Method must be %s, but is %s", p1.Method, p2.Method)
+	}
+	r1 := p1.Transform(x)
+	r2 := p2.Transform(x)
+	for idx := 0; idx < r1.Size(); idx++ {
+		if floatEquals(r1.Get(idx), r2.Get(idx), EPSILON) == false {
+			t.Errorf("Deserialized preprocessor gives different result, %f != %f", r1.Get(idx), r2.Get(idx))
+		}
+	}
+}
+
+func TestPreprocessorGobDecodeRejectsInconsistentSizes(t *testing.T) {
+	// Arrange
+	p1 := CreatePreprocessor(ZCAWhitening)
+	p1.Fit(createSamples())
+	p1.scale = *LinAlg.MakeEmptyVector(3)
+	var buf bytes.Buffer
+	err := Utility.WriteGob(&buf, p1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	err = Utility.ReadGob(&buf, new(Preprocessor))
+
+	// Assert
+	if _, ok := err.(*LinAlg.DimensionError); ok == false {
+		t.Errorf("Expected *LinAlg.DimensionError, but got %v", err)
+	}
+}
@@ -42,7 +42,7 @@ func calculateDeltaCost(layer int, n *Network, mb *Minibatch, ts *MNISTImport.Tr
 	}
 	delta_next := calculateDeltaCost(layer+1, n, mb, ts)
 	s := mb.z[layer].F(SigmoidPrime)
-	delta := n.GetWeights(layer + 1).Transpose().Ax(delta_next).Hadamard(s)
+	delta := n.GetWeights(layer + 1).TransposeAx(delta_next).Hadamard(s)
 	return delta
 }
 
@@ -88,6 +88,7 @@ func (QuadraticCostFunction) CalculateErrorInOutputLayer(n *Network, outputActiv
 	// Equation (BP1) and (30), Chapter 2 of http://neuralnetworksanddeeplearning.com
 	outputLayerIdx := n.getOutputLayerIndex()
 	// Note: We assume that the output layer z has already been calculated in the feedforward step
-	delta := LinAlg.SubtractVectors(&mb.a[outputLayerIdx], outputActivations).Hadamard(mb.z[outputLayerIdx].F(SigmoidPrime))
-	mb.delta[outputLayerIdx] = *delta
+	delta := LinAlg.SubtractVectorsTo(&mb.delta[outputLayerIdx], &mb.a[outputLayerIdx], outputActivations)
+	s := LinAlg.FTo(&mb.s[outputLayerIdx], &mb.z[outputLayerIdx], SigmoidPrime)
+	LinAlg.HadamardTo(delta, delta, s)
 }
@@ -9,6 +9,9 @@ import (
 func main() {
 	fmt.Print("1. Train neural network with MNIST data\n")
 	fmt.Print("2. Run neural network on MNIST test data\n")
+	fmt.Print("3. Convert directory of labelled PNG images to IDX files\n")
+	fmt.Print("4. Classify PNG/JPEG digit image\n")
+	fmt.Print("5. Convert saved network to single precision\n")
 	idx := 1
 	fmt.Scanf("%d\n", &idx)
 
@@ -67,5 +70,65 @@ func main() {
 		// run against test data
 		accuracy := network.RunSamples(ts, true)
 		fmt.Printf("Accuracy: %f\n", accuracy)
+	} else if idx == 3 {
+		var imageDir, outputDir string
+		fmt.Print("Image directory (one subdirectory per label): ")
+		fmt.Scanf("%s\n", &imageDir)
+		fmt.Print("Output directory: ")
+		fmt.Scanf("%s\n", &outputDir)
+		answer := "y"
+		fmt.Print("Dark digits on light background, invert like MNIST? (y/n) [y]: ")
+		fmt.Scanf("%s\n", &answer)
+		invert := answer != "n" && answer != "N"
+		data, err := MNISTImport.ImportImageDirectory(imageDir, invert)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("Read %d images\n", data.Length())
+		err = data.Export(outputDir, "images.idx3-ubyte", "labels.idx1-ubyte")
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("Wrote %s and %s to %s\n", "images.idx3-ubyte", "labels.idx1-ubyte", outputDir)
+	} else if idx == 4 {
+		filename := "./n.gob"
+		fmt.Printf("Deserializing network from %s...\n", filename)
+		network := new(Network)
+		err := Utility.ReadGobFromFile(filename, network)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		var imageFile string
+		fmt.Print("Image file: ")
+		fmt.Scanf("%s\n", &imageFile)
+		input, err := MNISTImport.ImportDigitImage(imageFile)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		class, probabilities := network.Classify(input)
+		fmt.Printf("Classified as %d\n", class)
+		for idx, p := range probabilities {
+			fmt.Printf("%d: %f\n", idx, p)
+		}
+	} else if idx == 5 {
+		filename := "./n.gob"
+		fmt.Printf("Deserializing network from %s...\n", filename)
+		network := new(Network)
+		err := Utility.ReadGobFromFile(filename, network)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		network32 := network.ToFloat32()
+		filename = "./n32.gob"
+		fmt.Printf("Serializing single precision network to %s...\n", filename)
+		err = Utility.WriteGobToFile(filename, &network32)
+		if err != nil {
+			fmt.Println(err)
+		}
 	}
 }